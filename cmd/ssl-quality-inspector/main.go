@@ -3,113 +3,420 @@ package main
 import (
 	"flag"
 	"fmt"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/alert"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/api"
 	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/clock"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/inspector"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/metrics"
 	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/network"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/persistence"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/recorder"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/referee"
 	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/sslnet"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/tracker"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/tui"
 	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/vision"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/web"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/protobuf/proto"
 	"log"
-	"sort"
+	"os"
 	"strings"
 	"time"
 )
 
+// alertInterval is the time between two evaluations of the alert rules
+const alertInterval = time.Millisecond * 200
+
 var visionAddress = flag.String("visionAddress", "224.5.23.2:10006", "The multicast address of ssl-vision")
+var logFile = flag.String("logFile", "", "An SSL log file (optionally gzip compressed) to analyse instead of listening to ssl-vision")
+var refereeAddress = flag.String("refereeAddress", "224.5.23.1:10003", "The multicast address of the game controller")
+var onlyDuringPlay = flag.Bool("onlyDuringPlay", false, "Only collect ball and robot statistics while the game is running")
+var trackerEnabled = flag.Bool("tracker", false, "Also analyse the tracked frames of tracker sources")
+var trackerAddress = flag.String("trackerAddress", "224.5.23.2:10010", "The multicast address of tracker sources")
+var replaySpeed = flag.Float64("replaySpeed", 1, "The replay speed for log files relative to the recording, zero or less for as fast as possible")
+
+var showCoverage = flag.Bool("showCoverage", false, "Show a heat map of the detection quality on the field in plain mode")
+var coverageFile = flag.String("coverageFile", "", "A PNG or SVG file to write the heat map of the detection quality to after replaying a log file")
+var coverageCellSize = flag.Float64("coverageCellSize", 0.25, "The cell size of the detection quality heat map in meters")
+var recordDir = flag.String("recordDir", "", "A directory to record statistics to, disabled if empty")
+var recordFormat = flag.String("recordFormat", "jsonl", "The format of recorded statistics: jsonl or csv")
+var recordInterval = flag.Duration("recordInterval", time.Second, "The time between two recorded snapshots of the statistics")
+var recordMaxFileSize = flag.Int64("recordMaxFileSize", 100, "The file size in MB after which a new recording file is started, zero to disable")
+var recordMaxFileAge = flag.Duration("recordMaxFileAge", time.Hour, "The duration after which a new recording file is started, zero to disable")
+var alertRules = flag.String("alertRules", "", "A file with alert rules, one per line like 'low-fps: camera.fps < 55 for 2s'")
+var visibleRobotQuality = flag.Float64("visibleRobotQuality", 0.5, "The minimum detection quality of a robot to be counted as visible")
+var qualityThresholdLow = flag.Float64("qualityThresholdLow", 0.3, "Quality values below this threshold are shown in red")
+var qualityThresholdHigh = flag.Float64("qualityThresholdHigh", 0.6, "Quality values below this threshold are shown in yellow")
+var plain = flag.Bool("plain", false, "Periodically print the statistics instead of showing the interactive terminal UI")
+var percentiles = flag.String("percentiles", "0.9,0.95,0.99", "Comma separated percentiles that are calculated for timings")
+var histogramBuckets = flag.String("histogramBuckets", "1ms,2ms,5ms,10ms,15ms,20ms,30ms,50ms,100ms,200ms,500ms", "Comma separated upper bounds of the timing histogram buckets")
+var httpAddress = flag.String("httpAddress", "", "The address for serving the web dashboard, HTTP JSON API and Prometheus metrics, like ':8090', disabled if empty")
+var dashboardInterval = flag.Duration("dashboardInterval", time.Millisecond*200, "The time between two updates of the web dashboard")
 
 var timeWindowClock = flag.Duration("timeWindowClock", time.Millisecond*500, "The time window for watching clock timing")
 var timeWindowVisibility = flag.Duration("timeWindowVisibility", time.Second*5, "The time window for taking timing statistics")
 var timeWindowQualityCam = flag.Duration("timeWindowQualityCam", time.Millisecond*500, "The time window for measuring the camera quality")
 var timeWindowQualityBall = flag.Duration("timeWindowQualityBall", time.Millisecond*200, "The time window for measuring the ball quality")
 var timeWindowQualityRobot = flag.Duration("timeWindowQualityRobot", time.Millisecond*500, "The time window for measuring the robot quality")
+var timeWindowReprojection = flag.Duration("timeWindowReprojection", time.Second*10, "The time window for measuring the reprojection error of detections")
+var timeWindowReferee = flag.Duration("timeWindowReferee", time.Second*2, "The time window for measuring the referee packet rate")
+var timeWindowNetwork = flag.Duration("timeWindowNetwork", time.Second*2, "The time window for measuring packet statistics of the multicast streams")
+var timeWindowTracker = flag.Duration("timeWindowTracker", time.Second*5, "The time window for measuring tracker statistics")
+var timeWindowCapture = flag.Duration("timeWindowCapture", time.Second*10, "The time window for the capture interval and the drift of the capture clocks")
+var timeWindowBlob = flag.Duration("timeWindowBlob", time.Second*30, "The time window for the confidence, blob area and height of ball detections")
+var timeWindowRobotIds = flag.Duration("timeWindowRobotIds", time.Second*10, "The time window for the id stability of robots")
+var timeWindowStationary = flag.Duration("timeWindowStationary", time.Second*2, "The time window for measuring the position noise of stationary objects")
+var stationaryRadius = flag.Float64("stationaryRadius", 0.05, "The maximum deviation from the mean position in meters for an object to be detected as stationary")
+var assumeStationary = flag.Bool("assumeStationary", false, "Treat all objects as stationary for measuring the position noise, for example during the field setup")
+var timeWindowCrossCam = flag.Duration("timeWindowCrossCam", time.Second*5, "The time window for comparing detections of different cameras")
+var maxCrossCamTimeDiff = flag.Duration("maxCrossCamTimeDiff", time.Millisecond*10, "The maximum difference of capture times for comparing detections of different cameras")
+var maxDuplicateTimeDiff = flag.Duration("maxDuplicateTimeDiff", time.Millisecond*20, "The maximum difference of capture times for checking frames of different cameras for duplicate robot ids")
 
 func main() {
 
 	flag.Parse()
 
 	multicastSources := network.NewMulticastSourceWatcher()
-	go multicastSources.Watch(*visionAddress)
 
+	var replayClock *timing.ManualClock
 	var statsConfig vision.StatsConfig
+	if *logFile != "" {
+		// use the receive time of the recorded messages
+		replayClock = timing.NewManualClock(time.Time{})
+		statsConfig.Clock = replayClock
+	}
 	statsConfig.TimeWindowVisibility = *timeWindowVisibility
 	statsConfig.TimeWindowQualityCam = *timeWindowQualityCam
 	statsConfig.TimeWindowQualityBall = *timeWindowQualityBall
 	statsConfig.TimeWindowQualityRobot = *timeWindowQualityRobot
+	statsConfig.TimeWindowReprojection = *timeWindowReprojection
+	statsConfig.TimeWindowCapture = *timeWindowCapture
+	statsConfig.TimeWindowBlob = *timeWindowBlob
+	statsConfig.TimeWindowRobotIds = *timeWindowRobotIds
+	statsConfig.TimeWindowStationary = *timeWindowStationary
+	statsConfig.StationaryRadius = *stationaryRadius
+	statsConfig.AssumeStationary = *assumeStationary
+	statsConfig.TimeWindowCrossCam = *timeWindowCrossCam
+	statsConfig.MaxCrossCamTimeDiff = *maxCrossCamTimeDiff
+	statsConfig.MaxDuplicateTimeDiff = *maxDuplicateTimeDiff
+	statsConfig.CoverageCellSize = *coverageCellSize
+	statsConfig.VisibleRobotQuality = *visibleRobotQuality
+	statsConfig.QualityThresholds = timing.QualityThresholds{Low: *qualityThresholdLow, High: *qualityThresholdHigh}
+	statsConfig.OnlyDuringPlay = *onlyDuringPlay
+	if p, err := timing.ParsePercentiles(*percentiles); err != nil {
+		log.Fatalf("Invalid percentiles %v: %v", *percentiles, err)
+	} else {
+		statsConfig.Distribution.Percentiles = p
+	}
+	if buckets, err := timing.ParseDurations(*histogramBuckets); err != nil {
+		log.Fatalf("Invalid histogram buckets %v: %v", *histogramBuckets, err)
+	} else {
+		statsConfig.Distribution.HistogramBuckets = buckets
+	}
 	stats := vision.NewStats(statsConfig)
-	mcServer := sslnet.NewMulticastServer(func(bytes []byte) {
+	visionPackets := network.NewPacketStats("vision", *timeWindowNetwork, stats.Clock)
+	processVision := func(bytes []byte, packet sslnet.Packet) {
+		addPacket(visionPackets, packet)
 		wrapper := new(vision.SSL_WrapperPacket)
 		if err := proto.Unmarshal(bytes, wrapper); err != nil {
 			log.Println("Could not unmarshal message")
+			visionPackets.AddUnmarshalFailure(packetSource(packet))
 		} else {
-			stats.Process(wrapper)
+			stats.Process(packetSource(packet), wrapper)
 		}
-	})
-	mcServer.Start(*visionAddress)
+	}
 
-	clockWatchers := map[string]*clock.Watcher{}
-	activeSources := map[string]bool{}
+	refereeStats := referee.NewStats(*timeWindowReferee, stats.Clock)
+	refereeSources := network.NewMulticastSourceWatcher()
+	refereePackets := network.NewPacketStats("referee", *timeWindowNetwork, stats.Clock)
+	processReferee := func(bytes []byte, packet sslnet.Packet) {
+		addPacket(refereePackets, packet)
+		msg := new(referee.Referee)
+		if err := proto.Unmarshal(bytes, msg); err != nil {
+			log.Println("Could not unmarshal referee message")
+			refereePackets.AddUnmarshalFailure(packetSource(packet))
+		} else {
+			events := refereeStats.Process(msg)
+			stats.SetGameState(refereeStats.GameState)
+			for _, event := range events {
+				stats.AddLog(stats.Clock.Now(), event)
+			}
+		}
+	}
 
-	for {
-		stats.Mutex.Lock()
-
-		multicastSources := multicastSources.GetSources()
-		for _, source := range multicastSources {
-			if _, ok := activeSources[source]; !ok {
-				clockWatcher := clock.NewWatcher(*timeWindowClock)
-				clockWatchers[source] = clockWatcher
-				go clockWatcher.Watch(source)
-				activeSources[source] = true
+	var trackerStats *tracker.Stats
+	var trackerPackets *network.PacketStats
+	var processTracker func([]byte, sslnet.Packet)
+	if *trackerEnabled {
+		trackerStats = tracker.NewStats(*timeWindowTracker, stats.Distribution, stats.Clock)
+		trackerPackets = network.NewPacketStats("tracker", *timeWindowNetwork, stats.Clock)
+		processTracker = func(bytes []byte, packet sslnet.Packet) {
+			addPacket(trackerPackets, packet)
+			wrapper := new(tracker.TrackerWrapperPacket)
+			if err := proto.Unmarshal(bytes, wrapper); err != nil {
+				log.Println("Could not unmarshal tracker message")
+				trackerPackets.AddUnmarshalFailure(packetSource(packet))
+			} else {
+				trackerStats.Process(wrapper)
 			}
 		}
+	}
 
-		watcherDataMap := map[string]clock.Data{}
-		for source, clockWatcher := range clockWatchers {
-			watcherDataMap[source] = *clockWatcher.GetData()
+	if *logFile != "" {
+		go func() {
+			replay(*logFile, *replaySpeed, replayClock, processVision, processReferee, processTracker)
+			if *coverageFile != "" {
+				writeCoverage(stats, *coverageFile)
+			}
+		}()
+	} else {
+		go multicastSources.Watch(*visionAddress)
+		mcServer := sslnet.NewMulticastServer(processVision)
+		mcServer.Start(*visionAddress)
+		go refereeSources.Watch(*refereeAddress)
+		refereeServer := sslnet.NewMulticastServer(processReferee)
+		refereeServer.Start(*refereeAddress)
+		if processTracker != nil {
+			trackerServer := sslnet.NewMulticastServer(processTracker)
+			trackerServer.Start(*trackerAddress)
 		}
+	}
 
-		// clear screen, move cursor to upper left corner
-		fmt.Print("\033[H\033[2J")
+	clockWatchers := clock.NewWatchers(*timeWindowClock)
+	insp := inspector.NewInspector(stats, multicastSources, clockWatchers, refereeStats, refereeSources)
+	insp.Tracker = trackerStats
+	insp.Packets = []*network.PacketStats{visionPackets, refereePackets}
+	if trackerPackets != nil {
+		insp.Packets = append(insp.Packets, trackerPackets)
+	}
 
-		fmt.Println("Vision Multicast sources:")
-		fmt.Println(strings.Join(multicastSources, " "))
+	if *alertRules != "" {
+		rules, err := alert.LoadRules(*alertRules)
+		if err != nil {
+			log.Fatalf("Could not load alert rules from %v: %v", *alertRules, err)
+		}
+		insp.Alerts = alert.NewEngine(rules)
+		go insp.WatchAlerts(alertInterval)
+	}
 
-		fmt.Println()
-		fmt.Println("Reference clocks:")
-		for source, watcherData := range watcherDataMap {
-			fmt.Println(source, " ClockOffset: ", watcherData.ClockOffset)
-			fmt.Println(source, "         RTT: ", watcherData.RTT)
+	if *httpAddress != "" {
+		apiServer := api.NewServer(insp)
+		prometheus.MustRegister(metrics.NewExporter(insp))
+		apiServer.Mux.Handle("/metrics", promhttp.Handler())
+		dashboard := web.NewDashboard(insp)
+		dashboard.Interval = *dashboardInterval
+		dashboard.Register(apiServer.Mux)
+		go dashboard.Broadcast()
+		go apiServer.ListenAndServe(*httpAddress)
+	}
+
+	if *recordDir != "" {
+		rec, err := recorder.NewRecorder(insp, *recordDir, recorder.Format(*recordFormat))
+		if err != nil {
+			log.Fatal("Could not create recorder: ", err)
+		}
+		rec.Interval = *recordInterval
+		rec.MaxFileSize = *recordMaxFileSize * 1024 * 1024
+		rec.MaxFileAge = *recordMaxFileAge
+		go func() {
+			if err := rec.Record(); err != nil {
+				log.Println("Could not record statistics: ", err)
+			}
+		}()
+	}
+
+	go updateClocks(multicastSources, clockWatchers)
+
+	if *plain {
+		for {
+			printStats(insp)
+			time.Sleep(time.Second)
 		}
+	}
+
+	app := tui.NewApp(insp)
+	log.SetOutput(app.LogWriter())
+	if err := app.Run(time.Second); err != nil {
+		log.SetOutput(os.Stderr)
+		log.Fatal("Could not run terminal UI: ", err)
+	}
+}
+
+// updateClocks periodically updates the clock watchers with the current vision sources
+func updateClocks(sources *network.MulticastSourceWatcher, clockWatchers *clock.Watchers) {
+	for {
+		clockWatchers.Update(sources.GetSources())
+		time.Sleep(time.Second)
+	}
+}
+
+// printStats clears the screen and prints all statistics
+func printStats(insp *inspector.Inspector) {
+	stats := insp.Stats
+	clockWatchers := insp.Clocks
+	refereeStats := insp.Referee
+	refereeSources := insp.RefereeSources
+	trackerStats := insp.Tracker
+	sources := insp.Sources.GetSources()
+
+	stats.Mutex.Lock()
+
+	// clear screen, move cursor to upper left corner
+	fmt.Print("\033[H\033[2J")
+
+	fmt.Println("Vision Multicast sources:")
+	fmt.Println(strings.Join(sources, " "))
 
+	fmt.Println()
+	fmt.Println("Reference clocks:")
+	for _, source := range clockWatchers.Hosts() {
+		watcherData := clockWatchers.Get(source).GetData()
+		fmt.Println(source, " ClockOffset: ", watcherData.ClockOffset)
+		fmt.Println(source, "         RTT: ", watcherData.RTT)
+	}
+
+	fmt.Println()
+	fmt.Println("Game controller:")
+	refereeStats.Mutex.Lock()
+	fmt.Print(refereeStats)
+	refereeStats.Mutex.Unlock()
+	if refereeIps := refereeSources.GetSources(); len(refereeIps) > 1 {
+		fmt.Println("Multiple game controller source IPs:", strings.Join(refereeIps, " "))
+	}
+
+	fmt.Println()
+	fmt.Println("Geometry:")
+	fmt.Print(stats.Geometry)
+	if missing := stats.Geometry.MissingCalibrations(stats.SortedCamIds()); len(missing) > 0 {
+		fmt.Println("Missing calibration for cameras:", missing)
+	}
+
+	fmt.Println()
+	fmt.Println("Vision:")
+	for _, key := range stats.SortedCamKeys() {
+		fmt.Print("Camera ", key.CamId, " from ", key.Source)
+		fmt.Println(stats.CamStats[key])
 		fmt.Println()
-		fmt.Println("Vision:")
-		for camId := range sortedCamIds(stats.CamStats) {
-			fmt.Print("Camera ", camId)
-			fmt.Println(stats.CamStats[camId])
-			fmt.Println()
+	}
+	for _, camSources := range stats.CamSources.Snapshot(stats.Clock.Now()) {
+		if camSources.Conflict {
+			fmt.Printf("Camera %v is published by multiple hosts: %v (%v switches)\n",
+				camSources.CameraId, strings.Join(camSources.Active, " "), camSources.NumSwitches)
+		}
+	}
+	for _, duplicate := range stats.Duplicates.Snapshot() {
+		if duplicate.Active {
+			fmt.Printf("Duplicate %v\n", duplicate)
 		}
+	}
 
-		numLogs := len(stats.LogList)
-		nEntries := 20
-		oldest := numLogs - 1 - nEntries
-		if oldest < 0 {
-			oldest = 0
+	if *showCoverage {
+		if coverage, ok := stats.Coverage.Heatmap(-1); ok {
+			fmt.Println("Coverage:")
+			fmt.Print(coverage.Terminal())
+			fmt.Println()
 		}
-		for i := oldest; i < numLogs; i++ {
-			fmt.Println(stats.LogList[i])
+	}
+
+	if insp.Alerts != nil {
+		fmt.Println("Alerts:")
+		for _, a := range insp.Alerts.Active() {
+			fmt.Println(a)
 		}
+		fmt.Println()
+	}
+
+	fmt.Println("Network:")
+	for _, packetStats := range insp.Packets {
+		fmt.Print(packetStats)
+	}
+	fmt.Println()
 
+	if trackerStats != nil {
+		trackerStats.Mutex.Lock()
+		fmt.Println("Tracker:")
+		fmt.Print(trackerStats)
 		fmt.Println()
+		trackerStats.Mutex.Unlock()
+	}
 
-		stats.Mutex.Unlock()
+	fmt.Println("Camera overlap:")
+	fmt.Print(stats.CrossCam)
+	fmt.Println()
 
-		time.Sleep(time.Second)
+	for _, entry := range stats.LogList.Latest(20) {
+		fmt.Println(entry)
+	}
+
+	fmt.Println()
+
+	stats.Mutex.Unlock()
+}
+
+// replaySource is the packet source of messages replayed from a log file
+const replaySource = "log"
+
+// packetSource returns the sender IP of a packet
+func packetSource(packet sslnet.Packet) string {
+	if packet.Source == nil {
+		return replaySource
 	}
+	return packet.Source.IP.String()
 }
 
-func sortedCamIds(camStats map[int]*vision.CamStats) []int {
-	keys := make([]int, 0, len(camStats))
-	for k := range camStats {
-		keys = append(keys, k)
+func addPacket(packetStats *network.PacketStats, packet sslnet.Packet) {
+	packetStats.Add(packetSource(packet), packet.Received, packet.Size, packet.Truncated)
+}
+
+// replay replays vision, referee and, if processTracker is not nil, tracker messages from a log file
+func replay(filename string, speed float64, replayClock *timing.ManualClock,
+	processVision, processReferee, processTracker func([]byte, sslnet.Packet)) {
+	reader, err := persistence.NewReader(filename)
+	if err != nil {
+		log.Fatalf("Could not open log file %v: %v", filename, err)
+	}
+	defer func() {
+		if err := reader.Close(); err != nil {
+			log.Println("Could not close log file: ", err)
+		}
+	}()
+
+	replayer := persistence.NewReplayer(reader, speed)
+	err = replayer.Replay(func(msg *persistence.Message) {
+		packet := sslnet.Packet{Received: msg.Time(), Size: len(msg.Message)}
+		switch msg.MessageType {
+		case persistence.MessageSslVision2014:
+			replayClock.Set(msg.Time())
+			processVision(msg.Message, packet)
+		case persistence.MessageSslRefbox2013:
+			replayClock.Set(msg.Time())
+			processReferee(msg.Message, packet)
+		case persistence.MessageSslVisionTracker2020:
+			if processTracker != nil {
+				replayClock.Set(msg.Time())
+				processTracker(msg.Message, packet)
+			}
+		}
+	})
+	if err != nil {
+		log.Printf("Could not replay log file %v: %v", filename, err)
+	} else {
+		log.Printf("Finished replaying log file %v", filename)
+	}
+}
+
+func writeCoverage(stats *vision.Stats, filename string) {
+	coverage, ok := stats.CoverageHeatmap(-1)
+	if !ok {
+		log.Println("No coverage available, geometry is missing")
+		return
+	}
+	if err := coverage.WriteFile(filename, 20); err != nil {
+		log.Printf("Could not write coverage to %v: %v", filename, err)
+	} else {
+		log.Printf("Wrote coverage to %v", filename)
 	}
-	sort.Ints(keys)
-	return keys
 }
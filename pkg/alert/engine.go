@@ -0,0 +1,181 @@
+package alert
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxHistory is the number of ended alerts that are kept
+const maxHistory = 100
+
+// Alert is raised when a rule is fulfilled for a subject
+type Alert struct {
+	Rule    string     `json:"rule"`
+	Subject string     `json:"subject"`
+	Message string     `json:"message"`
+	Value   float64    `json:"value"`
+	Start   time.Time  `json:"start"`
+	End     *time.Time `json:"end,omitempty"`
+}
+
+// Listener is called when an alert starts or ends
+type Listener func(alert Alert)
+
+// Engine evaluates rules and keeps track of active alerts
+type Engine struct {
+	rules     []Rule
+	pending   map[alertKey]time.Time
+	active    map[alertKey]*Alert
+	history   []Alert
+	listeners []Listener
+	mutex     sync.Mutex
+}
+
+type alertKey struct {
+	rule    int
+	subject string
+}
+
+func NewEngine(rules []Rule) (e *Engine) {
+	e = new(Engine)
+	e.rules = rules
+	e.pending = map[alertKey]time.Time{}
+	e.active = map[alertKey]*Alert{}
+	return e
+}
+
+// AddListener registers a listener for started and ended alerts
+func (e *Engine) AddListener(listener Listener) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.listeners = append(e.listeners, listener)
+}
+
+func (e *Engine) Rules() []Rule {
+	return e.rules
+}
+
+// Evaluate checks all rules and starts or ends alerts
+func (e *Engine) Evaluate(input Input) {
+	// listeners are notified without holding the lock
+	for _, alert := range e.evaluate(input) {
+		for _, listener := range e.listenersCopy() {
+			listener(alert)
+		}
+	}
+}
+
+func (e *Engine) listenersCopy() []Listener {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	listeners := make([]Listener, len(e.listeners))
+	copy(listeners, e.listeners)
+	return listeners
+}
+
+// evaluate updates all alerts and returns the alerts that started or ended
+func (e *Engine) evaluate(input Input) (changed []Alert) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	for ruleIdx, rule := range e.rules {
+		values := rule.extract(input)
+		for subject, value := range values {
+			key := alertKey{rule: ruleIdx, subject: subject}
+			var alert *Alert
+			if rule.Operator.Compare(value, rule.Threshold) {
+				alert = e.fulfilled(key, rule, value, input.Time)
+			} else {
+				alert = e.notFulfilled(key, input.Time)
+			}
+			if alert != nil {
+				changed = append(changed, *alert)
+			}
+		}
+		// subjects that disappeared can not fulfill the rule anymore
+		for key := range e.pending {
+			if _, ok := values[key.subject]; key.rule == ruleIdx && !ok {
+				if alert := e.notFulfilled(key, input.Time); alert != nil {
+					changed = append(changed, *alert)
+				}
+			}
+		}
+	}
+	return
+}
+
+// fulfilled updates the alert of a fulfilled rule and returns the alert if it started
+func (e *Engine) fulfilled(key alertKey, rule Rule, value float64, t time.Time) *Alert {
+	since, ok := e.pending[key]
+	if !ok {
+		since = t
+		e.pending[key] = since
+	}
+	if alert, ok := e.active[key]; ok {
+		alert.Value = value
+		return nil
+	}
+	if t.Sub(since) >= rule.For {
+		alert := &Alert{
+			Rule:    rule.Name,
+			Subject: key.subject,
+			Message: fmt.Sprintf("%v: %v is %.4g (%v)", rule.Name, key.subject, value, rule),
+			Value:   value,
+			Start:   since,
+		}
+		e.active[key] = alert
+		return alert
+	}
+	return nil
+}
+
+// notFulfilled updates the alert of a rule that is not fulfilled and returns the alert if it ended
+func (e *Engine) notFulfilled(key alertKey, t time.Time) *Alert {
+	delete(e.pending, key)
+	if alert, ok := e.active[key]; ok {
+		delete(e.active, key)
+		end := t
+		alert.End = &end
+		e.history = append(e.history, *alert)
+		if len(e.history) > maxHistory {
+			e.history = e.history[len(e.history)-maxHistory:]
+		}
+		return alert
+	}
+	return nil
+}
+
+// Active returns all active alerts ordered by start time
+func (e *Engine) Active() []Alert {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	alerts := []Alert{}
+	for _, alert := range e.active {
+		alerts = append(alerts, *alert)
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		if !alerts[i].Start.Equal(alerts[j].Start) {
+			return alerts[i].Start.Before(alerts[j].Start)
+		}
+		return alerts[i].Message < alerts[j].Message
+	})
+	return alerts
+}
+
+// History returns the most recent ended alerts
+func (e *Engine) History() []Alert {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	alerts := make([]Alert, len(e.history))
+	copy(alerts, e.history)
+	return alerts
+}
+
+func (a Alert) String() string {
+	if a.End != nil {
+		return fmt.Sprintf("%v (ended after %v)", a.Message, a.End.Sub(a.Start).Round(time.Millisecond))
+	}
+	return fmt.Sprintf("%v (since %v)", a.Message, a.Start.Format("15:04:05.000"))
+}
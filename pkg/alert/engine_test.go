@@ -0,0 +1,87 @@
+package alert
+
+import (
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/vision"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules(strings.NewReader(`
+# comment
+low-fps: camera.fps < 55 for 2s
+latency: camera.receiving.median > 20ms
+bad-robot: robot.quality <= 80%
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 3 {
+		t.Fatalf("Parsed %v rules, expected 3", len(rules))
+	}
+	if rules[0].Name != "low-fps" || rules[0].Operator != OperatorLess || rules[0].Threshold != 55 || rules[0].For != 2*time.Second {
+		t.Errorf("Unexpected rule: %+v", rules[0])
+	}
+	if rules[1].Threshold != 0.02 {
+		t.Errorf("Threshold %v != 0.02", rules[1].Threshold)
+	}
+	if rules[2].Threshold != 0.8 {
+		t.Errorf("Threshold %v != 0.8", rules[2].Threshold)
+	}
+
+	if _, err := ParseRule("x: camera.processing.p50 > 10ms"); err != nil {
+		t.Errorf("Unexpected error for a percentile: %v", err)
+	}
+	if _, err := ParseRule("x: camera.processing.p101 > 10ms"); err == nil {
+		t.Error("Expected an error for a percentile above 100")
+	}
+	if _, err := ParseRule("x: camera.unknown < 1"); err == nil {
+		t.Error("Expected an error for an unknown metric")
+	}
+	if _, err := ParseRule("x: camera.fps ~ 1"); err == nil {
+		t.Error("Expected an error for an unknown operator")
+	}
+}
+
+func cameraInput(t time.Time, fps float32) Input {
+	return Input{
+		Time: t,
+		Vision: vision.StatsSnapshot{Cameras: []vision.CamSnapshot{
+			{CameraId: 1, Primary: true, Frames: timing.FrameStatsSnapshot{Fps: fps}},
+		}},
+	}
+}
+
+func TestEngine_Evaluate(t *testing.T) {
+	rule, err := ParseRule("low-fps: camera.fps < 55 for 2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine := NewEngine([]Rule{rule})
+	var events []Alert
+	engine.AddListener(func(alert Alert) {
+		events = append(events, alert)
+	})
+
+	tStart := time.Unix(1000, 0)
+	engine.Evaluate(cameraInput(tStart, 50))
+	engine.Evaluate(cameraInput(tStart.Add(time.Second), 50))
+	if len(engine.Active()) != 0 {
+		t.Error("Alert started before the duration of the rule passed")
+	}
+
+	engine.Evaluate(cameraInput(tStart.Add(2*time.Second), 50))
+	if len(events) != 1 || events[0].Subject != "camera 1" || !events[0].Start.Equal(tStart) {
+		t.Fatalf("Expected a started alert, got %v", events)
+	}
+
+	engine.Evaluate(cameraInput(tStart.Add(3*time.Second), 60))
+	if len(events) != 2 || events[1].End == nil || !events[1].End.Equal(tStart.Add(3*time.Second)) {
+		t.Fatalf("Expected an ended alert, got %v", events)
+	}
+	if len(engine.Active()) != 0 || len(engine.History()) != 1 {
+		t.Errorf("Unexpected active %v or history %v", engine.Active(), engine.History())
+	}
+}
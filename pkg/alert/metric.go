@@ -0,0 +1,256 @@
+package alert
+
+import (
+	"fmt"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/clock"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/network"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/vision"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Input contains all statistics that rules are evaluated on
+type Input struct {
+	Time    time.Time
+	Vision  vision.StatsSnapshot
+	Clocks  []clock.DataSnapshot
+	Network []network.PacketStatsSnapshot
+}
+
+// extractor returns the current value of a metric for each subject, like a camera or a robot
+type extractor func(input Input) map[string]float64
+
+var cameraMetrics = map[string]func(cam vision.CamSnapshot) float64{
+	"fps":                   func(cam vision.CamSnapshot) float64 { return float64(cam.Frames.Fps) },
+	"quality":               func(cam vision.CamSnapshot) float64 { return cam.Frames.Quality },
+	"deltaTime":             func(cam vision.CamSnapshot) float64 { return cam.Frames.DeltaTime },
+	"deltaTimeSigma":        func(cam vision.CamSnapshot) float64 { return cam.Frames.DeltaTimeSigma },
+	"visibleBlue":           func(cam vision.CamSnapshot) float64 { return float64(cam.NumVisibleBlue) },
+	"visibleYellow":         func(cam vision.CamSnapshot) float64 { return float64(cam.NumVisibleYellow) },
+	"balls":                 func(cam vision.CamSnapshot) float64 { return float64(len(cam.Balls)) },
+	"reprojectionError":     func(cam vision.CamSnapshot) float64 { return cam.Reprojection.Error.Mean },
+	"lostFrames":            func(cam vision.CamSnapshot) float64 { return float64(cam.Sequence.NumLost) },
+	"duplicateFrames":       func(cam vision.CamSnapshot) float64 { return float64(cam.Sequence.NumDuplicates) },
+	"reorderedFrames":       func(cam vision.CamSnapshot) float64 { return float64(cam.Sequence.NumReordered) },
+	"frameResets":           func(cam vision.CamSnapshot) float64 { return float64(cam.Sequence.NumResets) },
+	"orientationNoise":      func(cam vision.CamSnapshot) float64 { return cam.OrientationNoise },
+	"orientationFlips":      func(cam vision.CamSnapshot) float64 { return float64(cam.NumOrientationFlips) },
+	"nonMonotonicCaptures":  func(cam vision.CamSnapshot) float64 { return float64(cam.Capture.NumNonMonotonic) },
+	"missingCaptures":       func(cam vision.CamSnapshot) float64 { return float64(cam.Capture.NumMissingCaptures) },
+	"captureDrift":          func(cam vision.CamSnapshot) float64 { return math.Abs(cam.Capture.Drift) },
+	"relativeCaptureDrift":  func(cam vision.CamSnapshot) float64 { return math.Abs(cam.Capture.RelativeDrift) },
+	"relativeCaptureOffset": func(cam vision.CamSnapshot) float64 { return math.Abs(cam.Capture.RelativeOffset.Seconds()) },
+	"stationaryNoise":       func(cam vision.CamSnapshot) float64 { return cam.Stationary.StdDev },
+	"stationaryObjects":     func(cam vision.CamSnapshot) float64 { return float64(cam.Stationary.NumObjects) },
+	"ballConfidence":        func(cam vision.CamSnapshot) float64 { return cam.BallBlobs.Confidence.Mean },
+	"ballArea":              func(cam vision.CamSnapshot) float64 { return cam.BallBlobs.Area.Mean },
+	"ballAreaRatio":         func(cam vision.CamSnapshot) float64 { return cam.BallBlobs.AreaRatio.Mean },
+	"ballConfidenceTrend":   func(cam vision.CamSnapshot) float64 { return cam.BallBlobs.ConfidenceTrend },
+	"ballAreaRatioTrend":    func(cam vision.CamSnapshot) float64 { return cam.BallBlobs.AreaRatioTrend },
+	"ballHeights":           func(cam vision.CamSnapshot) float64 { return float64(cam.BallBlobs.NumHeights) },
+	"idStability":           func(cam vision.CamSnapshot) float64 { return cam.IdStability },
+	"idChanges":             func(cam vision.CamSnapshot) float64 { return float64(cam.NumIdChanges) },
+	"idFlickers":            func(cam vision.CamSnapshot) float64 { return float64(cam.NumIdFlickers) },
+	"idPhantoms":            func(cam vision.CamSnapshot) float64 { return float64(cam.NumIdPhantoms) },
+	"duplicateIds":          func(cam vision.CamSnapshot) float64 { return float64(cam.NumDuplicateIds) },
+	"activeDuplicateIds":    func(cam vision.CamSnapshot) float64 { return float64(cam.ActiveDuplicateIds) },
+}
+
+var cameraTimings = map[string]func(cam vision.CamSnapshot) timing.TimingSnapshot{
+	"processing":      func(cam vision.CamSnapshot) timing.TimingSnapshot { return cam.TimingProcessing },
+	"receiving":       func(cam vision.CamSnapshot) timing.TimingSnapshot { return cam.TimingReceiving },
+	"captureInterval": func(cam vision.CamSnapshot) timing.TimingSnapshot { return cam.Capture.Interval },
+}
+
+// Metrics returns the names of all supported metrics
+func Metrics() (names []string) {
+	for name := range cameraMetrics {
+		names = append(names, "camera."+name)
+	}
+	for timingName := range cameraTimings {
+		for _, stat := range timingStats() {
+			names = append(names, "camera."+timingName+"."+stat)
+		}
+	}
+	names = append(names, "robot.quality", "robot.orientationNoise", "robot.stationaryNoise", "robot.idStability", "ball.quality", "clock.offset", "clock.rtt",
+		"cameraPair.robotDistance", "cameraPair.ballDistance", "cameraPair.robotOrientation",
+		"network.packetRate", "network.bytesPerSecond", "network.jitter", "network.maxGap", "network.unmarshalFailures")
+	sort.Strings(names)
+	return
+}
+
+// timingStats returns the statistics of timings, percentiles are only available if they are configured for the timings
+func timingStats() []string {
+	stats := []string{"min", "max", "avg", "median", "stdDev"}
+	for _, p := range timing.DefaultDistribution().Percentiles {
+		stats = append(stats, timing.PercentileName(p))
+	}
+	return stats
+}
+
+func metricExtractor(metric string) (extractor, error) {
+	parts := strings.Split(metric, ".")
+	switch {
+	case len(parts) == 2 && parts[0] == "camera":
+		if value, ok := cameraMetrics[parts[1]]; ok {
+			return perCamera(value), nil
+		}
+	case len(parts) == 3 && parts[0] == "camera" && cameraTimings[parts[1]] != nil:
+		stat, ok := timingStat(parts[2])
+		if !ok {
+			break
+		}
+		return perCameraTiming(cameraTimings[parts[1]], stat), nil
+	case metric == "robot.quality":
+		return perRobot(func(robot vision.RobotSnapshot) float64 { return robot.Frames.Quality }), nil
+	case metric == "robot.orientationNoise":
+		return perRobot(func(robot vision.RobotSnapshot) float64 { return robot.Orientation.Noise }), nil
+	case metric == "robot.idStability":
+		return perRobot(func(robot vision.RobotSnapshot) float64 { return robot.Ids.Stability }), nil
+	case metric == "robot.stationaryNoise":
+		return perRobot(func(robot vision.RobotSnapshot) float64 { return robot.Stationary.StdDev }), nil
+	case metric == "ball.quality":
+		return ballQuality, nil
+	case metric == "clock.offset":
+		return perClock(func(c clock.DataSnapshot) float64 { return math.Abs(c.ClockOffset.Median.Seconds()) }), nil
+	case metric == "clock.rtt":
+		return perClock(func(c clock.DataSnapshot) float64 { return c.RTT.Median.Seconds() }), nil
+	case metric == "cameraPair.robotDistance":
+		return perCameraPair(func(p vision.CamPairSnapshot) (float64, bool) {
+			return p.Robots.MeanDistance, p.Robots.NumSamples > 0
+		}), nil
+	case metric == "cameraPair.ballDistance":
+		return perCameraPair(func(p vision.CamPairSnapshot) (float64, bool) {
+			return p.Balls.MeanDistance, p.Balls.NumSamples > 0
+		}), nil
+	case metric == "cameraPair.robotOrientation":
+		return perCameraPair(func(p vision.CamPairSnapshot) (float64, bool) {
+			return math.Abs(p.Robots.MeanOrientation), p.Robots.NumOrientationSamples > 0
+		}), nil
+	case metric == "network.packetRate":
+		return perPacketSource(func(s network.SourcePacketStatsSnapshot) float64 { return float64(s.PacketRate) }), nil
+	case metric == "network.bytesPerSecond":
+		return perPacketSource(func(s network.SourcePacketStatsSnapshot) float64 { return s.BytesPerSecond }), nil
+	case metric == "network.jitter":
+		return perPacketSource(func(s network.SourcePacketStatsSnapshot) float64 { return s.InterArrival.StdDev.Seconds() }), nil
+	case metric == "network.maxGap":
+		return perPacketSource(func(s network.SourcePacketStatsSnapshot) float64 { return s.InterArrival.Max.Seconds() }), nil
+	case metric == "network.unmarshalFailures":
+		return perPacketSource(func(s network.SourcePacketStatsSnapshot) float64 { return float64(s.NumUnmarshalFailures) }), nil
+	}
+	return nil, fmt.Errorf("unknown metric '%v', supported metrics: %v", metric, strings.Join(Metrics(), ", "))
+}
+
+// timingStat returns a function that extracts the given statistic in seconds,
+// if it is available in the snapshot
+func timingStat(name string) (func(s timing.TimingSnapshot) (float64, bool), bool) {
+	switch name {
+	case "min":
+		return func(s timing.TimingSnapshot) (float64, bool) { return s.Min.Seconds(), true }, true
+	case "max":
+		return func(s timing.TimingSnapshot) (float64, bool) { return s.Max.Seconds(), true }, true
+	case "avg":
+		return func(s timing.TimingSnapshot) (float64, bool) { return s.Avg.Seconds(), true }, true
+	case "median":
+		return func(s timing.TimingSnapshot) (float64, bool) { return s.Median.Seconds(), true }, true
+	case "stdDev":
+		return func(s timing.TimingSnapshot) (float64, bool) { return s.StdDev.Seconds(), true }, true
+	}
+	if p, err := strconv.ParseFloat(strings.TrimPrefix(name, "p"), 64); err == nil && strings.HasPrefix(name, "p") && p > 0 && p <= 100 {
+		return func(s timing.TimingSnapshot) (float64, bool) {
+			d, ok := s.Percentile(name)
+			return d.Seconds(), ok
+		}, true
+	}
+	return nil, false
+}
+
+func perCamera(value func(cam vision.CamSnapshot) float64) extractor {
+	return func(input Input) map[string]float64 {
+		values := map[string]float64{}
+		for _, cam := range input.Vision.Cameras {
+			values[cam.Name()] = value(cam)
+		}
+		return values
+	}
+}
+
+func perCameraTiming(timingOf func(cam vision.CamSnapshot) timing.TimingSnapshot, stat func(s timing.TimingSnapshot) (float64, bool)) extractor {
+	return func(input Input) map[string]float64 {
+		values := map[string]float64{}
+		for _, cam := range input.Vision.Cameras {
+			if v, ok := stat(timingOf(cam)); ok {
+				values[fmt.Sprintf("camera %d", cam.CameraId)] = v
+			}
+		}
+		return values
+	}
+}
+
+func perClock(value func(c clock.DataSnapshot) float64) extractor {
+	return func(input Input) map[string]float64 {
+		values := map[string]float64{}
+		for _, c := range input.Clocks {
+			if c.Online && c.ClockOffset.NumMeasures > 0 {
+				values["clock "+c.Host] = value(c)
+			}
+		}
+		return values
+	}
+}
+
+func perCameraPair(value func(p vision.CamPairSnapshot) (float64, bool)) extractor {
+	return func(input Input) map[string]float64 {
+		values := map[string]float64{}
+		for _, pair := range input.Vision.CrossCam {
+			if v, ok := value(pair); ok {
+				values[fmt.Sprintf("cameras %d-%d", pair.CamA, pair.CamB)] = v
+			}
+		}
+		return values
+	}
+}
+
+func perPacketSource(value func(s network.SourcePacketStatsSnapshot) float64) extractor {
+	return func(input Input) map[string]float64 {
+		values := map[string]float64{}
+		for _, stream := range input.Network {
+			for _, source := range stream.Sources {
+				values[stream.Name+" "+source.Source] = value(source)
+			}
+		}
+		return values
+	}
+}
+
+func perRobot(value func(robot vision.RobotSnapshot) float64) extractor {
+	return func(input Input) map[string]float64 {
+		values := map[string]float64{}
+		qualities := map[string]float64{}
+		for _, cam := range input.Vision.Cameras {
+			for _, robot := range cam.Robots {
+				subject := fmt.Sprintf("robot %v%d %v", robot.Color, robot.Id, cam.Name())
+				// use the best track if there are multiple tracks for the same robot
+				if quality, ok := qualities[subject]; !ok || robot.Frames.Quality > quality {
+					qualities[subject] = robot.Frames.Quality
+					values[subject] = value(robot)
+				}
+			}
+		}
+		return values
+	}
+}
+
+func ballQuality(input Input) map[string]float64 {
+	values := map[string]float64{}
+	for _, cam := range input.Vision.Cameras {
+		for i, ball := range cam.Balls {
+			values[fmt.Sprintf("ball %d %v", i, cam.Name())] = ball.Frames.Quality
+		}
+	}
+	return values
+}
@@ -0,0 +1,138 @@
+package alert
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Operator compares a value to a threshold
+type Operator string
+
+const (
+	OperatorLess         Operator = "<"
+	OperatorLessEqual    Operator = "<="
+	OperatorGreater      Operator = ">"
+	OperatorGreaterEqual Operator = ">="
+)
+
+// Rule is a condition on a metric that raises an alert when it is fulfilled for a minimum duration
+type Rule struct {
+	Name      string
+	Metric    string
+	Operator  Operator
+	Threshold float64
+	For       time.Duration
+	extract   extractor
+}
+
+func (o Operator) Compare(value float64, threshold float64) bool {
+	switch o {
+	case OperatorLess:
+		return value < threshold
+	case OperatorLessEqual:
+		return value <= threshold
+	case OperatorGreater:
+		return value > threshold
+	case OperatorGreaterEqual:
+		return value >= threshold
+	}
+	return false
+}
+
+func (r Rule) String() string {
+	str := fmt.Sprintf("%v %v %v", r.Metric, r.Operator, r.Threshold)
+	if r.For > 0 {
+		str += fmt.Sprintf(" for %v", r.For)
+	}
+	return str
+}
+
+// LoadRules reads rules from a file
+func LoadRules(filename string) ([]Rule, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	return ParseRules(file)
+}
+
+// ParseRules reads one rule per line in the format '<name>: <metric> <operator> <threshold> [for <duration>]'.
+// Empty lines and lines starting with # are ignored. Thresholds can be numbers or durations like 20ms, durations are
+// converted to seconds.
+func ParseRules(reader io.Reader) (rules []Rule, err error) {
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule, err := ParseRule(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, scanner.Err()
+}
+
+// ParseRule parses a single rule in the format '<name>: <metric> <operator> <threshold> [for <duration>]'
+func ParseRule(line string) (rule Rule, err error) {
+	name, condition, found := strings.Cut(line, ":")
+	if !found {
+		return rule, fmt.Errorf("missing rule name in '%v'", line)
+	}
+	rule.Name = strings.TrimSpace(name)
+	fields := strings.Fields(condition)
+	if len(fields) != 3 && len(fields) != 5 {
+		return rule, fmt.Errorf("expected '<metric> <operator> <threshold> [for <duration>]', got '%v'", condition)
+	}
+
+	rule.Metric = fields[0]
+	if rule.extract, err = metricExtractor(rule.Metric); err != nil {
+		return
+	}
+
+	rule.Operator = Operator(fields[1])
+	switch rule.Operator {
+	case OperatorLess, OperatorLessEqual, OperatorGreater, OperatorGreaterEqual:
+	default:
+		return rule, fmt.Errorf("unknown operator '%v'", fields[1])
+	}
+
+	if rule.Threshold, err = parseThreshold(fields[2]); err != nil {
+		return
+	}
+
+	if len(fields) == 5 {
+		if fields[3] != "for" {
+			return rule, fmt.Errorf("expected 'for', got '%v'", fields[3])
+		}
+		if rule.For, err = time.ParseDuration(fields[4]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func parseThreshold(str string) (float64, error) {
+	if value, err := strconv.ParseFloat(str, 64); err == nil {
+		return value, nil
+	}
+	if strings.HasSuffix(str, "%") {
+		value, err := strconv.ParseFloat(strings.TrimSuffix(str, "%"), 64)
+		return value / 100, err
+	}
+	duration, err := time.ParseDuration(str)
+	if err != nil {
+		return 0, fmt.Errorf("invalid threshold '%v'", str)
+	}
+	return duration.Seconds(), nil
+}
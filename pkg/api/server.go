@@ -0,0 +1,169 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/alert"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/heatmap"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/inspector"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+const coveragePixelsPerCell = 20
+
+// Server serves the statistics of an inspector as JSON
+type Server struct {
+	inspector *inspector.Inspector
+	Mux       *http.ServeMux
+}
+
+func NewServer(inspector *inspector.Inspector) (s *Server) {
+	s = new(Server)
+	s.inspector = inspector
+	s.Mux = http.NewServeMux()
+	s.Mux.HandleFunc("/api/snapshot", s.handleSnapshot)
+	s.Mux.HandleFunc("/api/vision", s.handleVision)
+	s.Mux.HandleFunc("/api/geometry", s.handleGeometry)
+	s.Mux.HandleFunc("/api/coverage", s.handleCoverage)
+	s.Mux.HandleFunc("/api/coverage.png", s.handleCoveragePng)
+	s.Mux.HandleFunc("/api/coverage.svg", s.handleCoverageSvg)
+	s.Mux.HandleFunc("/api/coverage/reset", s.handleCoverageReset)
+	s.Mux.HandleFunc("/api/alerts", s.handleAlerts)
+	s.Mux.HandleFunc("/api/sources", s.handleSources)
+	s.Mux.HandleFunc("/api/clocks", s.handleClocks)
+	s.Mux.HandleFunc("/api/tracker", s.handleTracker)
+	s.Mux.HandleFunc("/api/referee", s.handleReferee)
+	s.Mux.HandleFunc("/api/network", s.handleNetwork)
+	s.Mux.HandleFunc("/api/stationary", s.handleStationary)
+	return s
+}
+
+// ListenAndServe starts serving on the given address and blocks until the server fails
+func (s *Server) ListenAndServe(address string) {
+	log.Println("Serving HTTP API on", address)
+	if err := http.ListenAndServe(address, s.Mux); err != nil {
+		log.Fatal("Could not serve HTTP API: ", err)
+	}
+}
+
+func (s *Server) handleSnapshot(w http.ResponseWriter, _ *http.Request) {
+	writeJson(w, s.inspector.Snapshot())
+}
+
+func (s *Server) handleVision(w http.ResponseWriter, _ *http.Request) {
+	writeJson(w, s.inspector.Snapshot().Vision)
+}
+
+func (s *Server) handleGeometry(w http.ResponseWriter, _ *http.Request) {
+	writeJson(w, s.inspector.Snapshot().Vision.Geometry)
+}
+
+func (s *Server) coverageHeatmap(w http.ResponseWriter, r *http.Request) (heatmap.Map, bool) {
+	camId := -1
+	if camera := r.URL.Query().Get("camera"); camera != "" {
+		var err error
+		if camId, err = strconv.Atoi(camera); err != nil {
+			http.Error(w, "Invalid camera id", http.StatusBadRequest)
+			return heatmap.Map{}, false
+		}
+	}
+	m, ok := s.inspector.Stats.CoverageHeatmap(camId)
+	if !ok {
+		http.Error(w, "No coverage available", http.StatusNotFound)
+	}
+	return m, ok
+}
+
+func (s *Server) handleCoverage(w http.ResponseWriter, r *http.Request) {
+	if m, ok := s.coverageHeatmap(w, r); ok {
+		writeJson(w, m)
+	}
+}
+
+func (s *Server) handleCoveragePng(w http.ResponseWriter, r *http.Request) {
+	if m, ok := s.coverageHeatmap(w, r); ok {
+		w.Header().Set("Content-Type", "image/png")
+		if err := m.WritePNG(w, coveragePixelsPerCell); err != nil {
+			log.Println("Could not write PNG response: ", err)
+		}
+	}
+}
+
+func (s *Server) handleCoverageSvg(w http.ResponseWriter, r *http.Request) {
+	if m, ok := s.coverageHeatmap(w, r); ok {
+		w.Header().Set("Content-Type", "image/svg+xml")
+		if err := m.WriteSVG(w); err != nil {
+			log.Println("Could not write SVG response: ", err)
+		}
+	}
+}
+
+func (s *Server) handleCoverageReset(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	s.inspector.Stats.ResetCoverage()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type alertsResponse struct {
+	Rules   []string      `json:"rules"`
+	Active  []alert.Alert `json:"active"`
+	History []alert.Alert `json:"history"`
+}
+
+func (s *Server) handleAlerts(w http.ResponseWriter, _ *http.Request) {
+	response := alertsResponse{Rules: []string{}, Active: []alert.Alert{}, History: []alert.Alert{}}
+	if s.inspector.Alerts != nil {
+		for _, rule := range s.inspector.Alerts.Rules() {
+			response.Rules = append(response.Rules, rule.Name+": "+rule.String())
+		}
+		response.Active = s.inspector.Alerts.Active()
+		response.History = s.inspector.Alerts.History()
+	}
+	writeJson(w, response)
+}
+
+func (s *Server) handleSources(w http.ResponseWriter, _ *http.Request) {
+	writeJson(w, s.inspector.Sources.GetSources())
+}
+
+func (s *Server) handleClocks(w http.ResponseWriter, _ *http.Request) {
+	writeJson(w, s.inspector.Clocks.Snapshot())
+}
+
+func (s *Server) handleTracker(w http.ResponseWriter, _ *http.Request) {
+	writeJson(w, s.inspector.Snapshot().Tracker)
+}
+
+func (s *Server) handleReferee(w http.ResponseWriter, _ *http.Request) {
+	writeJson(w, s.inspector.Snapshot().Referee)
+}
+
+func (s *Server) handleNetwork(w http.ResponseWriter, _ *http.Request) {
+	writeJson(w, s.inspector.Snapshot().Network)
+}
+
+func (s *Server) handleStationary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	assumeStationary, err := strconv.ParseBool(r.URL.Query().Get("assume"))
+	if err != nil {
+		http.Error(w, "Invalid value for assume", http.StatusBadRequest)
+		return
+	}
+	s.inspector.Stats.SetAssumeStationary(assumeStationary)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJson(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Println("Could not write JSON response: ", err)
+	}
+}
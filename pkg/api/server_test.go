@@ -0,0 +1,175 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/inspector"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/network"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/vision"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func testServer() (*Server, *httptest.Server) {
+	insp := inspector.NewTestInspector(timing.NewManualClock(time.Unix(1000, 0)))
+	inspector.AddTestFrames(insp.Stats)
+	server := NewServer(insp)
+	return server, httptest.NewServer(server.Mux)
+}
+
+func getJson(t *testing.T, url string, data interface{}) {
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("Unexpected response for %v: %v %v", url, resp.Status, resp.Header.Get("Content-Type"))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServer_Json(t *testing.T) {
+	_, httpServer := testServer()
+	defer httpServer.Close()
+
+	var snapshot map[string]json.RawMessage
+	getJson(t, httpServer.URL+"/api/snapshot", &snapshot)
+	for _, key := range []string{"time", "sources", "clocks", "vision", "alerts", "tracker", "referee", "network"} {
+		if _, ok := snapshot[key]; !ok {
+			t.Errorf("Missing %v in snapshot", key)
+		}
+	}
+
+	var visionSnapshot vision.StatsSnapshot
+	getJson(t, httpServer.URL+"/api/vision", &visionSnapshot)
+	if len(visionSnapshot.Cameras) != 2 || len(visionSnapshot.Cameras[0].Balls) != 1 || len(visionSnapshot.Cameras[0].Robots) != 1 {
+		t.Fatalf("Expected camera 0 of both sources with a ball and a robot, got %+v", visionSnapshot.Cameras)
+	}
+	if visionSnapshot.Cameras[0].Source != inspector.TestSources[0] || visionSnapshot.Cameras[1].Source != inspector.TestSources[1] {
+		t.Errorf("Expected the primary source first, got %v and %v", visionSnapshot.Cameras[0].Source, visionSnapshot.Cameras[1].Source)
+	}
+	if len(visionSnapshot.CamSources) != 1 || !visionSnapshot.CamSources[0].Conflict {
+		t.Errorf("Expected a source conflict of camera 0, got %+v", visionSnapshot.CamSources)
+	}
+
+	var geometry vision.GeometrySnapshot
+	getJson(t, httpServer.URL+"/api/geometry", &geometry)
+	if !geometry.Received || geometry.Field == nil || geometry.Field.FieldLength != 12000 {
+		t.Errorf("Unexpected geometry: %+v", geometry)
+	}
+
+	var coverage struct {
+		Cells [][]struct {
+			Count int `json:"count"`
+		} `json:"cells"`
+	}
+	getJson(t, httpServer.URL+"/api/coverage?camera=0", &coverage)
+	numDetections := 0
+	for _, row := range coverage.Cells {
+		for _, cell := range row {
+			numDetections += cell.Count
+		}
+	}
+	// only the primary source contributes to the coverage
+	if numDetections != 2 {
+		t.Errorf("Expected the ball and the robot of the primary source in the coverage, got %v detections", numDetections)
+	}
+
+	var alerts map[string][]json.RawMessage
+	getJson(t, httpServer.URL+"/api/alerts", &alerts)
+	for _, key := range []string{"rules", "active", "history"} {
+		if list, ok := alerts[key]; !ok || list == nil {
+			t.Errorf("Expected an empty list for %v, got %v", key, alerts)
+		}
+	}
+
+	var sources []string
+	getJson(t, httpServer.URL+"/api/sources", &sources)
+	if sources == nil {
+		t.Error("Expected an empty list of sources")
+	}
+
+	var clocks []json.RawMessage
+	getJson(t, httpServer.URL+"/api/clocks", &clocks)
+	if clocks == nil {
+		t.Error("Expected an empty list of clocks")
+	}
+
+	var streams []network.PacketStatsSnapshot
+	getJson(t, httpServer.URL+"/api/network", &streams)
+	if streams == nil {
+		t.Error("Expected an empty list of network streams")
+	}
+}
+
+func TestServer_Status(t *testing.T) {
+	_, httpServer := testServer()
+	defer httpServer.Close()
+
+	tests := []struct {
+		method string
+		path   string
+		status int
+	}{
+		{http.MethodGet, "/api/coverage?camera=x", http.StatusBadRequest},
+		{http.MethodGet, "/api/coverage?camera=7", http.StatusNotFound},
+		{http.MethodGet, "/api/coverage.png", http.StatusOK},
+		{http.MethodGet, "/api/coverage.svg?camera=0", http.StatusOK},
+		{http.MethodGet, "/api/coverage/reset", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/api/coverage/reset", http.StatusNoContent},
+		{http.MethodGet, "/api/stationary?assume=true", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/api/stationary?assume=x", http.StatusBadRequest},
+		{http.MethodPost, "/api/stationary?assume=false", http.StatusNoContent},
+	}
+	for _, test := range tests {
+		req, err := http.NewRequest(test.method, httpServer.URL+test.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != test.status {
+			t.Errorf("%v %v: expected status %v, got %v", test.method, test.path, test.status, resp.StatusCode)
+		}
+	}
+}
+
+func TestServer_Stationary(t *testing.T) {
+	_, httpServer := testServer()
+	defer httpServer.Close()
+
+	resp, err := http.Post(httpServer.URL+"/api/stationary?assume=true", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("Expected status %v, got %v", http.StatusNoContent, resp.StatusCode)
+	}
+
+	var visionSnapshot map[string]json.RawMessage
+	getJson(t, httpServer.URL+"/api/vision", &visionSnapshot)
+	if string(visionSnapshot["assumeStationary"]) != "true" {
+		t.Errorf("Expected the stationary objects to be assumed, got %s", visionSnapshot["assumeStationary"])
+	}
+	var cameras []map[string]json.RawMessage
+	if err := json.Unmarshal(visionSnapshot["cameras"], &cameras); err != nil {
+		t.Fatal(err)
+	}
+	var stationary vision.CamStationarySnapshot
+	if len(cameras) == 0 || json.Unmarshal(cameras[0]["stationary"], &stationary) != nil {
+		t.Fatalf("Expected the stationary noise of camera 0, got %+v", cameras)
+	}
+	// a single detection is not enough to measure the noise
+	if stationary.NumObjects != 0 {
+		t.Errorf("Expected no measured objects, got %+v", stationary)
+	}
+}
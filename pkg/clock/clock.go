@@ -22,8 +22,8 @@ func NewWatcher(timeWindow time.Duration) (w *Watcher) {
 	w = new(Watcher)
 	w.online = false
 	w.data = new(Data)
-	w.data.ClockOffset = timing.NewTiming(timeWindow)
-	w.data.RTT = timing.NewTiming(timeWindow)
+	w.data.ClockOffset = timing.NewTiming(timeWindow, timing.WallClock{})
+	w.data.RTT = timing.NewTiming(timeWindow, timing.WallClock{})
 
 	return w
 }
@@ -0,0 +1,80 @@
+package clock
+
+import (
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Watchers manages a clock watcher for each known host
+type Watchers struct {
+	timeWindow time.Duration
+	watchers   map[string]*Watcher
+	mutex      sync.Mutex
+}
+
+// DataSnapshot is a copy of the current clock data of a host
+type DataSnapshot struct {
+	Host        string                `json:"host"`
+	Online      bool                  `json:"online"`
+	ClockOffset timing.TimingSnapshot `json:"clockOffset"`
+	RTT         timing.TimingSnapshot `json:"rtt"`
+}
+
+func NewWatchers(timeWindow time.Duration) (w *Watchers) {
+	w = new(Watchers)
+	w.timeWindow = timeWindow
+	w.watchers = map[string]*Watcher{}
+	return w
+}
+
+// Update starts watching all hosts that are not watched yet
+func (w *Watchers) Update(hosts []string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	for _, host := range hosts {
+		if _, ok := w.watchers[host]; !ok {
+			watcher := NewWatcher(w.timeWindow)
+			w.watchers[host] = watcher
+			go watcher.Watch(host)
+		}
+	}
+}
+
+// Hosts returns all watched hosts in sorted order
+func (w *Watchers) Hosts() []string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	hosts := make([]string, 0, len(w.watchers))
+	for host := range w.watchers {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+func (w *Watchers) Get(host string) *Watcher {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.watchers[host]
+}
+
+// Snapshot copies the current data of all watched hosts
+func (w *Watchers) Snapshot() []DataSnapshot {
+	snapshots := []DataSnapshot{}
+	for _, host := range w.Hosts() {
+		snapshots = append(snapshots, w.Get(host).Snapshot(host))
+	}
+	return snapshots
+}
+
+func (w *Watcher) Snapshot(host string) (s DataSnapshot) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	s.Host = host
+	s.Online = w.online
+	s.ClockOffset = w.data.ClockOffset.Snapshot()
+	s.RTT = w.data.RTT.Snapshot()
+	return
+}
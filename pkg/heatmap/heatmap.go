@@ -0,0 +1,76 @@
+package heatmap
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+)
+
+// Cell is a single cell of a heat map
+type Cell struct {
+	// Count is the number of samples in this cell, cells without samples are drawn as empty
+	Count int `json:"count"`
+	// Value is the value of this cell in [0, 1]
+	Value float64 `json:"value"`
+}
+
+// Line is a line in world coordinates that is drawn on top of the heat map
+type Line struct {
+	X1 float64 `json:"x1"`
+	Y1 float64 `json:"y1"`
+	X2 float64 `json:"x2"`
+	Y2 float64 `json:"y2"`
+}
+
+// Map is a grid of cells in world coordinates. The first row has the smallest y coordinate.
+type Map struct {
+	Cells    [][]Cell `json:"cells"`
+	MinX     float64  `json:"minX"`
+	MinY     float64  `json:"minY"`
+	CellSize float64  `json:"cellSize"`
+	Lines    []Line   `json:"lines"`
+}
+
+var emptyColor = color.RGBA{R: 60, G: 60, B: 60, A: 255}
+var lineColor = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+
+func (m *Map) Rows() int {
+	return len(m.Cells)
+}
+
+func (m *Map) Cols() int {
+	if len(m.Cells) == 0 {
+		return 0
+	}
+	return len(m.Cells[0])
+}
+
+// ValueColor maps a value in [0, 1] to a color from red over yellow to green
+func ValueColor(value float64) color.RGBA {
+	value = math.Max(0, math.Min(1, value))
+	if value < 0.5 {
+		return color.RGBA{R: 220, G: uint8(440 * value), B: 0, A: 255}
+	}
+	return color.RGBA{R: uint8(440 * (1 - value)), G: 220, B: 0, A: 255}
+}
+
+func cellColor(cell Cell) color.RGBA {
+	if cell.Count == 0 {
+		return emptyColor
+	}
+	return ValueColor(cell.Value)
+}
+
+// Terminal renders the map with ANSI true color codes, with the largest y coordinate at the top
+func (m *Map) Terminal() string {
+	var sb strings.Builder
+	for row := m.Rows() - 1; row >= 0; row-- {
+		for _, cell := range m.Cells[row] {
+			c := cellColor(cell)
+			sb.WriteString(fmt.Sprintf("\u001b[48;2;%d;%d;%dm  ", c.R, c.G, c.B))
+		}
+		sb.WriteString("\u001b[0m\n")
+	}
+	return sb.String()
+}
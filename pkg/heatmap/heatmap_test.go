@@ -0,0 +1,84 @@
+package heatmap
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/png"
+	"testing"
+)
+
+func testMap() *Map {
+	return &Map{
+		// the first row has the smallest y coordinate
+		Cells: [][]Cell{
+			{{Count: 1, Value: 1}, {Count: 0}, {Count: 2, Value: 0}},
+			{{Count: 1, Value: 0.5}, {Count: 1, Value: 1}, {Count: 1, Value: 1}},
+		},
+		MinX:     -1.5,
+		MinY:     -1,
+		CellSize: 1,
+		Lines:    []Line{{X1: -1.5, Y1: 0, X2: 1.5, Y2: 0}},
+	}
+}
+
+func TestMap_WritePNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testMap().WritePNG(&buf, 10); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := img.Bounds().Size(); size.X != 30 || size.Y != 20 {
+		t.Fatalf("Expected 30x20 pixels, got %v", size)
+	}
+	// the first row is at the bottom of the image
+	if c := img.At(5, 15); c != ValueColor(1) {
+		t.Errorf("Expected a green bottom left cell, got %v", c)
+	}
+	if c := img.At(15, 15); c != emptyColor {
+		t.Errorf("Expected an empty cell, got %v", c)
+	}
+	if c := img.At(25, 15); c != ValueColor(0) {
+		t.Errorf("Expected a red bottom right cell, got %v", c)
+	}
+	if c := img.At(5, 5); c != ValueColor(0.5) {
+		t.Errorf("Expected a yellow top left cell, got %v", c)
+	}
+	if c := img.At(15, 10); c != lineColor {
+		t.Errorf("Expected the line at y=0, got %v", c)
+	}
+}
+
+func TestMap_WriteSVG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testMap().WriteSVG(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var svg struct {
+		ViewBox string `xml:"viewBox,attr"`
+		Group   struct {
+			Rects []struct {
+				X     float64 `xml:"x,attr"`
+				Y     float64 `xml:"y,attr"`
+				Fill  string  `xml:"fill,attr"`
+				Title string  `xml:"title"`
+			} `xml:"rect"`
+			Lines []struct{} `xml:"line"`
+		} `xml:"g"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &svg); err != nil {
+		t.Fatal(err)
+	}
+	if svg.ViewBox != "-1.5 -1 3 2" {
+		t.Errorf("Unexpected view box: %v", svg.ViewBox)
+	}
+	if len(svg.Group.Rects) != 6 || len(svg.Group.Lines) != 1 {
+		t.Fatalf("Expected 6 cells and 1 line, got %v and %v", len(svg.Group.Rects), len(svg.Group.Lines))
+	}
+	last := svg.Group.Rects[5]
+	if last.X != 0.5 || last.Y != 0 || last.Fill != "#00dc00" || last.Title != "1 samples, 100%" {
+		t.Errorf("Unexpected last cell: %+v", last)
+	}
+}
@@ -0,0 +1,98 @@
+package heatmap
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+// WritePNG writes the map as PNG image with the given number of pixels per cell
+func (m *Map) WritePNG(w io.Writer, pixelsPerCell int) error {
+	width := m.Cols() * pixelsPerCell
+	height := m.Rows() * pixelsPerCell
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for row := 0; row < m.Rows(); row++ {
+		for col := 0; col < m.Cols(); col++ {
+			c := cellColor(m.Cells[row][col])
+			y0 := height - (row+1)*pixelsPerCell
+			for y := y0; y < y0+pixelsPerCell; y++ {
+				for x := col * pixelsPerCell; x < (col+1)*pixelsPerCell; x++ {
+					img.SetRGBA(x, y, c)
+				}
+			}
+		}
+	}
+
+	scale := float64(pixelsPerCell) / m.CellSize
+	for _, line := range m.Lines {
+		x1, y1 := m.toImage(line.X1, line.Y1, scale, height)
+		x2, y2 := m.toImage(line.X2, line.Y2, scale, height)
+		drawLine(img, x1, y1, x2, y2, lineColor)
+	}
+
+	return png.Encode(w, img)
+}
+
+func (m *Map) toImage(x, y, scale float64, height int) (float64, float64) {
+	return (x - m.MinX) * scale, float64(height) - (y-m.MinY)*scale
+}
+
+func drawLine(img *image.RGBA, x1, y1, x2, y2 float64, c color.RGBA) {
+	steps := int(math.Max(math.Abs(x2-x1), math.Abs(y2-y1))) + 1
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		img.SetRGBA(int(x1+t*(x2-x1)), int(y1+t*(y2-y1)), c)
+	}
+}
+
+// WriteSVG writes the map as SVG image in world coordinates
+func (m *Map) WriteSVG(w io.Writer) error {
+	width := float64(m.Cols()) * m.CellSize
+	height := float64(m.Rows()) * m.CellSize
+	// flip the y-axis so that the largest y coordinate is at the top
+	if _, err := fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="%g %g %g %g">`+"\n"+`<g transform="scale(1,-1)">`+"\n",
+		m.MinX, -(m.MinY + height), width, height); err != nil {
+		return err
+	}
+	for row := 0; row < m.Rows(); row++ {
+		for col := 0; col < m.Cols(); col++ {
+			cell := m.Cells[row][col]
+			c := cellColor(cell)
+			if _, err := fmt.Fprintf(w, `<rect x="%g" y="%g" width="%g" height="%g" fill="#%02x%02x%02x"><title>%d samples, %.0f%%</title></rect>`+"\n",
+				m.MinX+float64(col)*m.CellSize, m.MinY+float64(row)*m.CellSize, m.CellSize, m.CellSize,
+				c.R, c.G, c.B, cell.Count, cell.Value*100); err != nil {
+				return err
+			}
+		}
+	}
+	for _, line := range m.Lines {
+		if _, err := fmt.Fprintf(w, `<line x1="%g" y1="%g" x2="%g" y2="%g" stroke="white" stroke-width="%g"/>`+"\n",
+			line.X1, line.Y1, line.X2, line.Y2, m.CellSize/10); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "</g>\n</svg>")
+	return err
+}
+
+// WriteFile writes the map to a SVG file if the filename ends with .svg, else to a PNG file
+func (m *Map) WriteFile(filename string, pixelsPerCell int) (err error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	if strings.HasSuffix(strings.ToLower(filename), ".svg") {
+		return m.WriteSVG(file)
+	}
+	return m.WritePNG(file, pixelsPerCell)
+}
@@ -0,0 +1,78 @@
+package inspector
+
+import (
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/clock"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/network"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/referee"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/vision"
+	"google.golang.org/protobuf/proto"
+	"time"
+)
+
+// testTimeWindow is the time window of all statistics of a test inspector
+const testTimeWindow = time.Second
+
+// NewTestInspector creates an inspector for tests of the packages that serve statistics
+func NewTestInspector(statsClock timing.Clock) *Inspector {
+	stats := vision.NewStats(vision.StatsConfig{
+		Clock:                  statsClock,
+		TimeWindowVisibility:   testTimeWindow,
+		TimeWindowQualityCam:   testTimeWindow,
+		TimeWindowQualityBall:  testTimeWindow,
+		TimeWindowQualityRobot: testTimeWindow,
+		TimeWindowReprojection: testTimeWindow,
+		TimeWindowCapture:      testTimeWindow,
+		TimeWindowStationary:   testTimeWindow,
+		StationaryRadius:       0.05,
+		TimeWindowBlob:         testTimeWindow,
+		TimeWindowRobotIds:     testTimeWindow,
+		TimeWindowCrossCam:     testTimeWindow,
+		MaxCrossCamTimeDiff:    10 * time.Millisecond,
+		MaxDuplicateTimeDiff:   20 * time.Millisecond,
+		CoverageCellSize:       0.5,
+	})
+	return NewInspector(stats, network.NewMulticastSourceWatcher(), clock.NewWatchers(testTimeWindow),
+		referee.NewStats(testTimeWindow, statsClock), network.NewMulticastSourceWatcher())
+}
+
+// TestSources are the hosts that publish camera 0 in AddTestFrames, the first one is the primary source
+var TestSources = []string{"10.0.0.1", "10.0.0.99"}
+
+// AddTestFrames processes the field geometry of the primary source
+// and a frame of camera 0 with a ball and the blue robot 3 from each of the TestSources
+func AddTestFrames(stats *vision.Stats) {
+	stats.Process(TestSources[0], &vision.SSL_WrapperPacket{Geometry: &vision.SSL_GeometryData{
+		Field: &vision.SSL_GeometryFieldSize{
+			FieldLength:   proto.Int32(12000),
+			FieldWidth:    proto.Int32(9000),
+			GoalWidth:     proto.Int32(1800),
+			GoalDepth:     proto.Int32(180),
+			BoundaryWidth: proto.Int32(300),
+		},
+	}})
+	for _, source := range TestSources {
+		stats.Process(source, &vision.SSL_WrapperPacket{Detection: &vision.SSL_DetectionFrame{
+			FrameNumber: proto.Uint32(1),
+			TCapture:    proto.Float64(999.99),
+			TSent:       proto.Float64(1000),
+			CameraId:    proto.Uint32(0),
+			Balls: []*vision.SSL_DetectionBall{{
+				Confidence: proto.Float32(0.9),
+				Area:       proto.Uint32(80),
+				X:          proto.Float32(1000),
+				Y:          proto.Float32(500),
+				PixelX:     proto.Float32(100),
+				PixelY:     proto.Float32(200),
+			}},
+			RobotsBlue: []*vision.SSL_DetectionRobot{{
+				Confidence: proto.Float32(1),
+				RobotId:    proto.Uint32(3),
+				X:          proto.Float32(-1000),
+				Y:          proto.Float32(500),
+				PixelX:     proto.Float32(300),
+				PixelY:     proto.Float32(200),
+			}},
+		}})
+	}
+}
@@ -0,0 +1,104 @@
+package inspector
+
+import (
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/alert"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/clock"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/network"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/referee"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/tracker"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/vision"
+	"time"
+)
+
+// maxLogEntries is the number of most recent log entries included in a snapshot
+const maxLogEntries = 100
+
+// Inspector bundles all statistics that are collected
+type Inspector struct {
+	Stats   *vision.Stats
+	Sources *network.MulticastSourceWatcher
+	Clocks  *clock.Watchers
+	// Alerts is optional and may be nil
+	Alerts *alert.Engine
+	// Tracker is optional and may be nil
+	Tracker *tracker.Stats
+	Referee *referee.Stats
+	// RefereeSources are the source IPs of referee messages
+	RefereeSources *network.MulticastSourceWatcher
+	// Packets are the packet statistics per multicast stream
+	Packets []*network.PacketStats
+}
+
+// Snapshot is a copy of all statistics at a certain time
+type Snapshot struct {
+	Time    time.Time                     `json:"time"`
+	Sources []string                      `json:"sources"`
+	Clocks  []clock.DataSnapshot          `json:"clocks"`
+	Vision  vision.StatsSnapshot          `json:"vision"`
+	Alerts  []alert.Alert                 `json:"alerts"`
+	Tracker []tracker.SourceSnapshot      `json:"tracker"`
+	Referee RefereeSnapshot               `json:"referee"`
+	Network []network.PacketStatsSnapshot `json:"network"`
+}
+
+// RefereeSnapshot is a copy of the referee statistics including the source IPs of the game controllers
+type RefereeSnapshot struct {
+	referee.StatsSnapshot
+	SourceIps []string `json:"sourceIps"`
+}
+
+func NewInspector(stats *vision.Stats, sources *network.MulticastSourceWatcher, clocks *clock.Watchers,
+	refereeStats *referee.Stats, refereeSources *network.MulticastSourceWatcher) (i *Inspector) {
+	i = new(Inspector)
+	i.Stats = stats
+	i.Sources = sources
+	i.Clocks = clocks
+	i.Referee = refereeStats
+	i.RefereeSources = refereeSources
+	return i
+}
+
+func (i *Inspector) Snapshot() (s Snapshot) {
+	s.Time = i.Stats.Clock.Now()
+	s.Sources = i.Sources.GetSources()
+	s.Clocks = i.Clocks.Snapshot()
+	s.Vision = i.Stats.Snapshot(maxLogEntries)
+	s.Alerts = []alert.Alert{}
+	if i.Alerts != nil {
+		s.Alerts = i.Alerts.Active()
+	}
+	s.Tracker = []tracker.SourceSnapshot{}
+	if i.Tracker != nil {
+		s.Tracker = i.Tracker.Snapshot()
+	}
+	s.Referee.StatsSnapshot = i.Referee.Snapshot()
+	s.Referee.SourceIps = i.RefereeSources.GetSources()
+	s.Network = []network.PacketStatsSnapshot{}
+	for _, packetStats := range i.Packets {
+		s.Network = append(s.Network, packetStats.Snapshot())
+	}
+	return
+}
+
+// WatchAlerts evaluates the alert rules periodically and logs started and ended alerts
+func (i *Inspector) WatchAlerts(interval time.Duration) {
+	i.Alerts.AddListener(func(a alert.Alert) {
+		if a.End == nil {
+			i.Stats.AddLog(a.Start, "Alert started: "+a.Message)
+		} else {
+			i.Stats.AddLog(*a.End, "Alert ended: "+a.String())
+		}
+	})
+	for {
+		input := alert.Input{
+			Time:   i.Stats.Clock.Now(),
+			Vision: i.Stats.Snapshot(0),
+			Clocks: i.Clocks.Snapshot(),
+		}
+		for _, packetStats := range i.Packets {
+			input.Network = append(input.Network, packetStats.Snapshot())
+		}
+		i.Alerts.Evaluate(input)
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,422 @@
+package metrics
+
+import (
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/inspector"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/vision"
+	"github.com/prometheus/client_golang/prometheus"
+	"strconv"
+	"time"
+)
+
+const namespace = "ssl_quality"
+
+var (
+	cameraFpsDesc = prometheus.NewDesc(namespace+"_camera_fps",
+		"Frames per second received from a camera", []string{"source", "camera"}, nil)
+	cameraQualityDesc = prometheus.NewDesc(namespace+"_camera_frame_quality",
+		"Ratio of received frames to expected frames of a camera", []string{"source", "camera"}, nil)
+	cameraDeltaTimeDesc = prometheus.NewDesc(namespace+"_camera_delta_time_seconds",
+		"Mean time between two frames of a camera", []string{"source", "camera"}, nil)
+	cameraDeltaTimeSigmaDesc = prometheus.NewDesc(namespace+"_camera_delta_time_sigma_seconds",
+		"Standard deviation of the time between two frames of a camera", []string{"source", "camera"}, nil)
+	cameraProcessingDesc = prometheus.NewDesc(namespace+"_camera_processing_time_seconds",
+		"Processing time of ssl-vision within the time window", []string{"source", "camera", "stat"}, nil)
+	cameraReceivingDesc = prometheus.NewDesc(namespace+"_camera_receiving_time_seconds",
+		"Time between sending and receiving a frame within the time window", []string{"source", "camera", "stat"}, nil)
+	cameraVisibleRobotsDesc = prometheus.NewDesc(namespace+"_camera_visible_robots",
+		"Number of robots with a sufficient detection quality", []string{"source", "camera", "team"}, nil)
+	cameraBallsDesc = prometheus.NewDesc(namespace+"_camera_balls",
+		"Number of tracked balls", []string{"source", "camera"}, nil)
+	cameraFramesDesc = prometheus.NewDesc(namespace+"_camera_frames",
+		"Number of frames of a camera by their frame number order: inOrder, gap, duplicate, reordered or reset", []string{"source", "camera", "order"}, nil)
+	cameraCaptureIntervalDesc = prometheus.NewDesc(namespace+"_camera_capture_interval_seconds",
+		"Time between the capture of two consecutive frames within the time window", []string{"source", "camera", "stat"}, nil)
+	cameraCaptureDriftDesc = prometheus.NewDesc(namespace+"_camera_capture_drift_ppm",
+		"Drift of the capture clock of a camera relative to the receiving host", []string{"source", "camera"}, nil)
+	cameraCaptureRelativeDriftDesc = prometheus.NewDesc(namespace+"_camera_capture_relative_drift_ppm",
+		"Drift of the capture clock of a camera relative to the median of all cameras", []string{"source", "camera"}, nil)
+	cameraCaptureRelativeOffsetDesc = prometheus.NewDesc(namespace+"_camera_capture_relative_offset_seconds",
+		"Time between capturing and receiving a frame relative to the median of all cameras", []string{"source", "camera"}, nil)
+	cameraNonMonotonicCapturesDesc = prometheus.NewDesc(namespace+"_camera_non_monotonic_captures",
+		"Number of frames with a capture time that is not after the previous frame", []string{"source", "camera"}, nil)
+	cameraMissingCapturesDesc = prometheus.NewDesc(namespace+"_camera_missing_captures",
+		"Number of frames that are missing by capture time, but not by frame number", []string{"source", "camera"}, nil)
+	cameraLostFramesDesc = prometheus.NewDesc(namespace+"_camera_lost_frames",
+		"Number of frames of a camera that were skipped by the frame number", []string{"source", "camera"}, nil)
+	cameraSourcesDesc = prometheus.NewDesc(namespace+"_camera_sources",
+		"Number of hosts that published a camera within the time window, more than one indicates a conflict", []string{"camera"}, nil)
+	cameraSourceSwitchesDesc = prometheus.NewDesc(namespace+"_camera_source_switches",
+		"Number of frames of a camera that were published by another host than the previous frame", []string{"camera"}, nil)
+	cameraReprojectionDesc = prometheus.NewDesc(namespace+"_camera_reprojection_error_pixels",
+		"Error between detected and reprojected pixel positions within the time window", []string{"source", "camera", "stat"}, nil)
+	cameraPairDistanceDesc = prometheus.NewDesc(namespace+"_camera_pair_distance_meters",
+		"Mean distance between detections of the same object by two cameras", []string{"camera_a", "camera_b", "object"}, nil)
+	cameraPairOrientationDesc = prometheus.NewDesc(namespace+"_camera_pair_orientation_radians",
+		"Mean orientation difference between detections of the same robot by two cameras", []string{"camera_a", "camera_b"}, nil)
+	cameraOrientationNoiseDesc = prometheus.NewDesc(namespace+"_camera_orientation_noise_radians",
+		"Mean circular standard deviation of the orientation of all robots of a camera", []string{"source", "camera"}, nil)
+	cameraOrientationFlipsDesc = prometheus.NewDesc(namespace+"_camera_orientation_flips",
+		"Number of orientation changes of roughly 180° between two frames of a robot", []string{"source", "camera"}, nil)
+	cameraBallConfidenceDesc = prometheus.NewDesc(namespace+"_camera_ball_confidence",
+		"Confidence of the ball detections of a camera", []string{"source", "camera", "stat"}, nil)
+	cameraBallAreaDesc = prometheus.NewDesc(namespace+"_camera_ball_area_pixels",
+		"Blob area of the ball detections of a camera", []string{"source", "camera", "stat"}, nil)
+	cameraBallAreaRatioDesc = prometheus.NewDesc(namespace+"_camera_ball_area_ratio",
+		"Blob area of the ball detections relative to the expected area at the distance to the camera", []string{"source", "camera", "stat"}, nil)
+	cameraBallTrendDesc = prometheus.NewDesc(namespace+"_camera_ball_trend_per_minute",
+		"Change of the confidence and the area ratio of the ball detections per minute", []string{"source", "camera", "value"}, nil)
+	cameraBallHeightsDesc = prometheus.NewDesc(namespace+"_camera_ball_heights",
+		"Number of ball detections with a height above the ground", []string{"source", "camera"}, nil)
+	cameraBallHeightDesc = prometheus.NewDesc(namespace+"_camera_ball_height_meters",
+		"Height of the ball detections above the ground", []string{"source", "camera", "stat"}, nil)
+	cameraStationaryObjectsDesc = prometheus.NewDesc(namespace+"_camera_stationary_objects",
+		"Number of stationary balls and robots of a camera", []string{"source", "camera"}, nil)
+	cameraStationaryNoiseDesc = prometheus.NewDesc(namespace+"_camera_stationary_noise_meters",
+		"Position noise of the stationary objects of a camera", []string{"source", "camera", "stat"}, nil)
+	cameraIdStabilityDesc = prometheus.NewDesc(namespace+"_camera_robot_id_stability",
+		"Fraction of robot detections of a camera with the most frequent id of their track", []string{"source", "camera"}, nil)
+	cameraIdEventsDesc = prometheus.NewDesc(namespace+"_camera_robot_id_events",
+		"Number of robot id changes, flickers and phantoms of a camera", []string{"source", "camera", "event"}, nil)
+	robotIdStabilityDesc = prometheus.NewDesc(namespace+"_robot_id_stability",
+		"Fraction of detections of a robot with its most frequent id", []string{"source", "camera", "team", "id"}, nil)
+	cameraDuplicateIdsDesc = prometheus.NewDesc(namespace+"_camera_duplicate_robot_ids",
+		"Number of robot ids detected at different places by a camera, in total or currently active", []string{"source", "camera", "state"}, nil)
+	robotDuplicateDesc = prometheus.NewDesc(namespace+"_robot_duplicate_seconds",
+		"Duration of an active duplicate of a robot id that is detected at different places", []string{"team", "id"}, nil)
+	robotOrientationNoiseDesc = prometheus.NewDesc(namespace+"_robot_orientation_noise_radians",
+		"Circular standard deviation of the orientation of a robot", []string{"source", "camera", "team", "id"}, nil)
+	robotQualityDesc = prometheus.NewDesc(namespace+"_robot_detection_quality",
+		"Ratio of frames in which a robot was detected", []string{"source", "camera", "team", "id"}, nil)
+	alertActiveDesc = prometheus.NewDesc(namespace+"_alert_active",
+		"Active alerts", []string{"rule", "subject"}, nil)
+	clockOffsetDesc = prometheus.NewDesc(namespace+"_clock_offset_seconds",
+		"Median NTP clock offset to a vision source", []string{"host"}, nil)
+	clockRttDesc = prometheus.NewDesc(namespace+"_clock_rtt_seconds",
+		"Median NTP round trip time to a vision source", []string{"host"}, nil)
+	clockOnlineDesc = prometheus.NewDesc(namespace+"_clock_online",
+		"Whether the NTP server of a vision source responds", []string{"host"}, nil)
+	refereePacketRateDesc = prometheus.NewDesc(namespace+"_referee_packet_rate",
+		"Referee packets per second received from a game controller", []string{"source"}, nil)
+	refereeCounterJumpsDesc = prometheus.NewDesc(namespace+"_referee_command_counter_jumps",
+		"Number of times the command counter of a game controller skipped commands", []string{"source"}, nil)
+	refereeCounterResetsDesc = prometheus.NewDesc(namespace+"_referee_command_counter_resets",
+		"Number of times the command counter of a game controller decreased", []string{"source"}, nil)
+	refereeSourceIpsDesc = prometheus.NewDesc(namespace+"_referee_source_ips",
+		"Number of IPs that sent referee messages", nil, nil)
+	gameRunningDesc = prometheus.NewDesc(namespace+"_game_running",
+		"Whether the game is running according to the game controller", nil, nil)
+	trackerFpsDesc = prometheus.NewDesc(namespace+"_tracker_fps",
+		"Frames per second received from a tracker source", []string{"uuid", "source"}, nil)
+	trackerQualityDesc = prometheus.NewDesc(namespace+"_tracker_frame_quality",
+		"Ratio of received frames to expected frames of a tracker source", []string{"uuid", "source"}, nil)
+	trackerLatencyDesc = prometheus.NewDesc(namespace+"_tracker_latency_seconds",
+		"Time between the tracker timestamp and receiving a frame within the time window", []string{"uuid", "source", "stat"}, nil)
+	trackerVelocityNoiseDesc = prometheus.NewDesc(namespace+"_tracker_velocity_noise_meters_per_second",
+		"Root mean square of the velocity change between two frames", []string{"uuid", "source", "object"}, nil)
+	networkPacketRateDesc = prometheus.NewDesc(namespace+"_network_packet_rate",
+		"Packets per second received from a multicast source", []string{"stream", "source"}, nil)
+	networkBytesDesc = prometheus.NewDesc(namespace+"_network_bytes_per_second",
+		"Bytes per second received from a multicast source", []string{"stream", "source"}, nil)
+	networkPacketSizeDesc = prometheus.NewDesc(namespace+"_network_packet_size_bytes",
+		"Size of the packets of a multicast source within the time window", []string{"stream", "source", "stat"}, nil)
+	networkInterArrivalDesc = prometheus.NewDesc(namespace+"_network_inter_arrival_seconds",
+		"Time between two packets of a multicast source within the time window", []string{"stream", "source", "stat"}, nil)
+	networkUnmarshalFailuresDesc = prometheus.NewDesc(namespace+"_network_unmarshal_failures",
+		"Number of packets of a multicast source that could not be decoded", []string{"stream", "source"}, nil)
+	networkTruncatedDesc = prometheus.NewDesc(namespace+"_network_truncated_packets",
+		"Number of packets of a multicast source that filled the receive buffer and were probably truncated", []string{"stream", "source"}, nil)
+)
+
+// Exporter exports the statistics of an inspector as Prometheus metrics
+type Exporter struct {
+	inspector         *inspector.Inspector
+	processingSeconds *prometheus.HistogramVec
+	receivingSeconds  *prometheus.HistogramVec
+}
+
+func NewExporter(inspector *inspector.Inspector) (e *Exporter) {
+	e = new(Exporter)
+	e.inspector = inspector
+	e.processingSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "camera_processing_seconds",
+		Help:      "Processing time of ssl-vision per frame",
+		Buckets:   latencyBuckets(inspector.Stats.Distribution),
+	}, []string{"camera"})
+	e.receivingSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "camera_receiving_seconds",
+		Help:      "Time between sending and receiving a frame",
+		Buckets:   latencyBuckets(inspector.Stats.Distribution),
+	}, []string{"camera"})
+	inspector.Stats.AddFrameListener(e.observeFrame)
+	return e
+}
+
+func (e *Exporter) observeFrame(camId int, processingTime time.Duration, receivingTime time.Duration) {
+	camera := strconv.Itoa(camId)
+	e.processingSeconds.WithLabelValues(camera).Observe(processingTime.Seconds())
+	e.receivingSeconds.WithLabelValues(camera).Observe(receivingTime.Seconds())
+}
+
+func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cameraFpsDesc
+	ch <- cameraQualityDesc
+	ch <- cameraDeltaTimeDesc
+	ch <- cameraDeltaTimeSigmaDesc
+	ch <- cameraProcessingDesc
+	ch <- cameraReceivingDesc
+	ch <- cameraVisibleRobotsDesc
+	ch <- cameraBallsDesc
+	ch <- cameraFramesDesc
+	ch <- cameraLostFramesDesc
+	ch <- cameraCaptureIntervalDesc
+	ch <- cameraCaptureDriftDesc
+	ch <- cameraCaptureRelativeDriftDesc
+	ch <- cameraCaptureRelativeOffsetDesc
+	ch <- cameraNonMonotonicCapturesDesc
+	ch <- cameraMissingCapturesDesc
+	ch <- cameraSourcesDesc
+	ch <- cameraSourceSwitchesDesc
+	ch <- cameraReprojectionDesc
+	ch <- cameraPairDistanceDesc
+	ch <- cameraPairOrientationDesc
+	ch <- robotQualityDesc
+	ch <- robotOrientationNoiseDesc
+	ch <- cameraIdStabilityDesc
+	ch <- cameraDuplicateIdsDesc
+	ch <- robotDuplicateDesc
+	ch <- cameraIdEventsDesc
+	ch <- robotIdStabilityDesc
+	ch <- cameraOrientationNoiseDesc
+	ch <- cameraOrientationFlipsDesc
+	ch <- cameraBallConfidenceDesc
+	ch <- cameraBallAreaDesc
+	ch <- cameraBallAreaRatioDesc
+	ch <- cameraBallTrendDesc
+	ch <- cameraBallHeightsDesc
+	ch <- cameraBallHeightDesc
+	ch <- cameraStationaryObjectsDesc
+	ch <- cameraStationaryNoiseDesc
+	ch <- alertActiveDesc
+	ch <- clockOffsetDesc
+	ch <- clockRttDesc
+	ch <- clockOnlineDesc
+	ch <- refereePacketRateDesc
+	ch <- refereeCounterJumpsDesc
+	ch <- refereeCounterResetsDesc
+	ch <- refereeSourceIpsDesc
+	ch <- gameRunningDesc
+	ch <- trackerFpsDesc
+	ch <- trackerQualityDesc
+	ch <- trackerLatencyDesc
+	ch <- trackerVelocityNoiseDesc
+	ch <- networkPacketRateDesc
+	ch <- networkBytesDesc
+	ch <- networkPacketSizeDesc
+	ch <- networkInterArrivalDesc
+	ch <- networkUnmarshalFailuresDesc
+	ch <- networkTruncatedDesc
+	e.processingSeconds.Describe(ch)
+	e.receivingSeconds.Describe(ch)
+}
+
+func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	snapshot := e.inspector.Snapshot()
+
+	for _, cam := range snapshot.Vision.Cameras {
+		camera := strconv.Itoa(cam.CameraId)
+		gauge(ch, cameraFpsDesc, float64(cam.Frames.Fps), cam.Source, camera)
+		gauge(ch, cameraQualityDesc, cam.Frames.Quality, cam.Source, camera)
+		gauge(ch, cameraDeltaTimeDesc, cam.Frames.DeltaTime, cam.Source, camera)
+		gauge(ch, cameraDeltaTimeSigmaDesc, cam.Frames.DeltaTimeSigma, cam.Source, camera)
+		timingGauges(ch, cameraProcessingDesc, cam.TimingProcessing, cam.Source, camera)
+		timingGauges(ch, cameraReceivingDesc, cam.TimingReceiving, cam.Source, camera)
+		gauge(ch, cameraVisibleRobotsDesc, float64(cam.NumVisibleBlue), cam.Source, camera, "blue")
+		gauge(ch, cameraVisibleRobotsDesc, float64(cam.NumVisibleYellow), cam.Source, camera, "yellow")
+		gauge(ch, cameraBallsDesc, float64(len(cam.Balls)), cam.Source, camera)
+		gauge(ch, cameraFramesDesc, float64(cam.Sequence.NumInOrder), cam.Source, camera, string(timing.FrameInOrder))
+		gauge(ch, cameraFramesDesc, float64(cam.Sequence.NumGaps), cam.Source, camera, string(timing.FrameGap))
+		gauge(ch, cameraFramesDesc, float64(cam.Sequence.NumDuplicates), cam.Source, camera, string(timing.FrameDuplicate))
+		gauge(ch, cameraFramesDesc, float64(cam.Sequence.NumReordered), cam.Source, camera, string(timing.FrameReordered))
+		gauge(ch, cameraFramesDesc, float64(cam.Sequence.NumResets), cam.Source, camera, string(timing.FrameReset))
+		gauge(ch, cameraLostFramesDesc, float64(cam.Sequence.NumLost), cam.Source, camera)
+		timingGauges(ch, cameraCaptureIntervalDesc, cam.Capture.Interval, cam.Source, camera)
+		gauge(ch, cameraCaptureDriftDesc, cam.Capture.Drift, cam.Source, camera)
+		gauge(ch, cameraCaptureRelativeDriftDesc, cam.Capture.RelativeDrift, cam.Source, camera)
+		gauge(ch, cameraCaptureRelativeOffsetDesc, cam.Capture.RelativeOffset.Seconds(), cam.Source, camera)
+		gauge(ch, cameraNonMonotonicCapturesDesc, float64(cam.Capture.NumNonMonotonic), cam.Source, camera)
+		gauge(ch, cameraMissingCapturesDesc, float64(cam.Capture.NumMissingCaptures), cam.Source, camera)
+		gauge(ch, cameraOrientationNoiseDesc, cam.OrientationNoise, cam.Source, camera)
+		gauge(ch, cameraOrientationFlipsDesc, float64(cam.NumOrientationFlips), cam.Source, camera)
+		gauge(ch, cameraIdStabilityDesc, cam.IdStability, cam.Source, camera)
+		gauge(ch, cameraDuplicateIdsDesc, float64(cam.NumDuplicateIds), cam.Source, camera, "total")
+		gauge(ch, cameraDuplicateIdsDesc, float64(cam.ActiveDuplicateIds), cam.Source, camera, "active")
+		gauge(ch, cameraIdEventsDesc, float64(cam.NumIdChanges), cam.Source, camera, "change")
+		gauge(ch, cameraIdEventsDesc, float64(cam.NumIdFlickers), cam.Source, camera, "flicker")
+		gauge(ch, cameraIdEventsDesc, float64(cam.NumIdPhantoms), cam.Source, camera, "phantom")
+		distributionGauges(ch, cameraBallConfidenceDesc, cam.BallBlobs.Confidence, cam.Source, camera)
+		distributionGauges(ch, cameraBallAreaDesc, cam.BallBlobs.Area, cam.Source, camera)
+		distributionGauges(ch, cameraBallAreaRatioDesc, cam.BallBlobs.AreaRatio, cam.Source, camera)
+		distributionGauges(ch, cameraBallHeightDesc, cam.BallBlobs.Height, cam.Source, camera)
+		gauge(ch, cameraBallHeightsDesc, float64(cam.BallBlobs.NumHeights), cam.Source, camera)
+		if cam.BallBlobs.Confidence.NumSamples > 1 {
+			gauge(ch, cameraBallTrendDesc, cam.BallBlobs.ConfidenceTrend, cam.Source, camera, "confidence")
+		}
+		if cam.BallBlobs.AreaRatio.NumSamples > 1 {
+			gauge(ch, cameraBallTrendDesc, cam.BallBlobs.AreaRatioTrend, cam.Source, camera, "areaRatio")
+		}
+		gauge(ch, cameraStationaryObjectsDesc, float64(cam.Stationary.NumObjects), cam.Source, camera)
+		if cam.Stationary.NumObjects > 0 {
+			gauge(ch, cameraStationaryNoiseDesc, cam.Stationary.StdDev, cam.Source, camera, "stddev")
+			gauge(ch, cameraStationaryNoiseDesc, cam.Stationary.MaxDeviation, cam.Source, camera, "max")
+		}
+		if cam.Reprojection.Error.NumSamples > 0 {
+			gauge(ch, cameraReprojectionDesc, cam.Reprojection.Error.Mean, cam.Source, camera, "mean")
+			gauge(ch, cameraReprojectionDesc, cam.Reprojection.Error.Max, cam.Source, camera, "max")
+		}
+		bestRobots := map[[2]string]vision.RobotSnapshot{}
+		for _, robot := range cam.Robots {
+			// multiple tracks may exist for the same robot, only export the best one
+			key := [2]string{teamLabel(robot.Color), strconv.Itoa(robot.Id)}
+			if best, ok := bestRobots[key]; !ok || robot.Frames.Quality > best.Frames.Quality {
+				bestRobots[key] = robot
+			}
+		}
+		for key, robot := range bestRobots {
+			gauge(ch, robotQualityDesc, robot.Frames.Quality, cam.Source, camera, key[0], key[1])
+			gauge(ch, robotOrientationNoiseDesc, robot.Orientation.Noise, cam.Source, camera, key[0], key[1])
+			gauge(ch, robotIdStabilityDesc, robot.Ids.Stability, cam.Source, camera, key[0], key[1])
+		}
+	}
+
+	for _, duplicate := range snapshot.Vision.Duplicates {
+		if duplicate.Active {
+			gauge(ch, robotDuplicateDesc, duplicate.Duration.Seconds(), teamLabel(duplicate.Color), strconv.Itoa(duplicate.Id))
+		}
+	}
+
+	for _, camSources := range snapshot.Vision.CamSources {
+		camera := strconv.Itoa(camSources.CameraId)
+		gauge(ch, cameraSourcesDesc, float64(len(camSources.Active)), camera)
+		gauge(ch, cameraSourceSwitchesDesc, float64(camSources.NumSwitches), camera)
+	}
+
+	for _, pair := range snapshot.Vision.CrossCam {
+		camA := strconv.Itoa(pair.CamA)
+		camB := strconv.Itoa(pair.CamB)
+		if pair.Robots.NumSamples > 0 {
+			gauge(ch, cameraPairDistanceDesc, pair.Robots.MeanDistance, camA, camB, "robot")
+		}
+		if pair.Balls.NumSamples > 0 {
+			gauge(ch, cameraPairDistanceDesc, pair.Balls.MeanDistance, camA, camB, "ball")
+		}
+		if pair.Robots.NumOrientationSamples > 0 {
+			gauge(ch, cameraPairOrientationDesc, pair.Robots.MeanOrientation, camA, camB)
+		}
+	}
+
+	for _, clock := range snapshot.Clocks {
+		online := 0.0
+		if clock.Online {
+			online = 1
+		}
+		gauge(ch, clockOnlineDesc, online, clock.Host)
+		if clock.ClockOffset.NumMeasures > 0 {
+			gauge(ch, clockOffsetDesc, clock.ClockOffset.Median.Seconds(), clock.Host)
+		}
+		if clock.RTT.NumMeasures > 0 {
+			gauge(ch, clockRttDesc, clock.RTT.Median.Seconds(), clock.Host)
+		}
+	}
+
+	for _, source := range snapshot.Referee.Sources {
+		gauge(ch, refereePacketRateDesc, float64(source.PacketRate), source.SourceIdentifier)
+		gauge(ch, refereeCounterJumpsDesc, float64(source.NumCounterJumps), source.SourceIdentifier)
+		gauge(ch, refereeCounterResetsDesc, float64(source.NumCounterResets), source.SourceIdentifier)
+	}
+	gauge(ch, refereeSourceIpsDesc, float64(len(snapshot.Referee.SourceIps)))
+	gameRunning := 0.0
+	if snapshot.Referee.GameState.IsRunning() {
+		gameRunning = 1
+	}
+	gauge(ch, gameRunningDesc, gameRunning)
+
+	for _, source := range snapshot.Tracker {
+		gauge(ch, trackerFpsDesc, float64(source.Frames.Fps), source.Uuid, source.SourceName)
+		gauge(ch, trackerQualityDesc, source.Frames.Quality, source.Uuid, source.SourceName)
+		timingGauges(ch, trackerLatencyDesc, source.TimingReceiving, source.Uuid, source.SourceName)
+		gauge(ch, trackerVelocityNoiseDesc, source.BallVelocityNoise, source.Uuid, source.SourceName, "ball")
+		gauge(ch, trackerVelocityNoiseDesc, source.RobotVelocityNoise, source.Uuid, source.SourceName, "robot")
+	}
+
+	for _, stream := range snapshot.Network {
+		for _, source := range stream.Sources {
+			gauge(ch, networkPacketRateDesc, float64(source.PacketRate), stream.Name, source.Source)
+			gauge(ch, networkBytesDesc, source.BytesPerSecond, stream.Name, source.Source)
+			gauge(ch, networkPacketSizeDesc, float64(source.Size.Min), stream.Name, source.Source, "min")
+			gauge(ch, networkPacketSizeDesc, float64(source.Size.Avg), stream.Name, source.Source, "avg")
+			gauge(ch, networkPacketSizeDesc, float64(source.Size.Max), stream.Name, source.Source, "max")
+			timingGauges(ch, networkInterArrivalDesc, source.InterArrival, stream.Name, source.Source)
+			gauge(ch, networkUnmarshalFailuresDesc, float64(source.NumUnmarshalFailures), stream.Name, source.Source)
+			gauge(ch, networkTruncatedDesc, float64(source.NumTruncated), stream.Name, source.Source)
+		}
+	}
+
+	for _, alert := range snapshot.Alerts {
+		gauge(ch, alertActiveDesc, 1, alert.Rule, alert.Subject)
+	}
+
+	e.processingSeconds.Collect(ch)
+	e.receivingSeconds.Collect(ch)
+}
+
+// latencyBuckets returns the timing histogram buckets in seconds
+func latencyBuckets(distribution timing.Distribution) []float64 {
+	buckets := make([]float64, len(distribution.HistogramBuckets))
+	for i, bucket := range distribution.HistogramBuckets {
+		buckets[i] = bucket.Seconds()
+	}
+	return buckets
+}
+
+// timingGauges exports the statistics of a timing with a stat label
+func timingGauges(ch chan<- prometheus.Metric, desc *prometheus.Desc, s timing.TimingSnapshot, labels ...string) {
+	if s.NumMeasures == 0 {
+		return
+	}
+	stat := func(name string, d time.Duration) {
+		gauge(ch, desc, d.Seconds(), append(labels, name)...)
+	}
+	stat("median", s.Median)
+	stat("max", s.Max)
+	stat("stdDev", s.StdDev)
+	for _, p := range s.Percentiles {
+		stat(p.Name, p.Duration)
+	}
+}
+
+func distributionGauges(ch chan<- prometheus.Metric, desc *prometheus.Desc, s vision.DistributionSnapshot, labels ...string) {
+	if s.NumSamples == 0 {
+		return
+	}
+	gauge(ch, desc, s.Mean, append(labels, "mean")...)
+	gauge(ch, desc, s.Min, append(labels, "min")...)
+	gauge(ch, desc, s.Max, append(labels, "max")...)
+	gauge(ch, desc, s.StdDev, append(labels, "stddev")...)
+}
+
+func gauge(ch chan<- prometheus.Metric, desc *prometheus.Desc, value float64, labels ...string) {
+	ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value, labels...)
+}
+
+func teamLabel(color vision.TeamColor) string {
+	switch color {
+	case vision.TeamBlue:
+		return "blue"
+	case vision.TeamYellow:
+		return "yellow"
+	}
+	return "unknown"
+}
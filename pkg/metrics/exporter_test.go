@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/inspector"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExporter_Collect(t *testing.T) {
+	insp := inspector.NewTestInspector(timing.NewManualClock(time.Unix(1000, 0)))
+	exporter := NewExporter(insp)
+	inspector.AddTestFrames(insp.Stats)
+
+	// the pedantic registry checks the collected metrics against their descriptions
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(exporter)
+	if _, err := registry.Gather(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `
+# HELP ssl_quality_camera_balls Number of tracked balls
+# TYPE ssl_quality_camera_balls gauge
+ssl_quality_camera_balls{camera="0",source="10.0.0.1"} 1
+ssl_quality_camera_balls{camera="0",source="10.0.0.99"} 1
+# HELP ssl_quality_camera_sources Number of hosts that published a camera within the time window, more than one indicates a conflict
+# TYPE ssl_quality_camera_sources gauge
+ssl_quality_camera_sources{camera="0"} 2
+`
+	if err := testutil.CollectAndCompare(exporter, strings.NewReader(expected),
+		"ssl_quality_camera_balls", "ssl_quality_camera_sources"); err != nil {
+		t.Error(err)
+	}
+	if n := testutil.CollectAndCount(exporter, "ssl_quality_robot_detection_quality"); n != 2 {
+		t.Errorf("Expected the robot quality of both sources, got %v series", n)
+	}
+	// median, max, stdDev and the default percentiles of both sources
+	if n := testutil.CollectAndCount(exporter, "ssl_quality_camera_processing_time_seconds"); n != 2*(3+len(timing.DefaultDistribution().Percentiles)) {
+		t.Errorf("Expected the processing time statistics of both sources, got %v series", n)
+	}
+	// the frame listener is only called for the primary source
+	if n := testutil.CollectAndCount(exporter.processingSeconds); n != 1 {
+		t.Errorf("Expected a processing time histogram of the primary source, got %v", n)
+	}
+}
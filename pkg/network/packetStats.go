@@ -0,0 +1,131 @@
+package network
+
+import (
+	"fmt"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"sort"
+	"sync"
+	"time"
+)
+
+// PacketSizeBuckets are the upper bounds of the packet size histogram in bytes
+var PacketSizeBuckets = []int{256, 512, 1024, 2048, 4096, 8192}
+
+// PacketStats collects packet level statistics of a stream of datagrams per source
+type PacketStats struct {
+	Name       string
+	Sources    map[string]*SourcePacketStats
+	timeWindow time.Duration
+	clock      timing.Clock
+	mutex      sync.Mutex
+}
+
+// SourcePacketStats collects packet level statistics of a single source
+type SourcePacketStats struct {
+	Source string
+	Fps    *timing.Fps
+	// InterArrival holds the time between two consecutive packets, its standard deviation is the jitter
+	InterArrival         *timing.Timing
+	NumPackets           int
+	NumBytes             int
+	NumUnmarshalFailures int
+	NumTruncated         int
+	LastReceived         time.Time
+	// sizes holds the size of each packet within the time window
+	sizes      *timing.TimeWindow[int]
+	timeWindow time.Duration
+	clock      timing.Clock
+}
+
+func NewPacketStats(name string, timeWindow time.Duration, clock timing.Clock) (s *PacketStats) {
+	s = new(PacketStats)
+	s.Name = name
+	s.Sources = map[string]*SourcePacketStats{}
+	s.timeWindow = timeWindow
+	s.clock = clock
+	return s
+}
+
+func NewSourcePacketStats(source string, timeWindow time.Duration, clock timing.Clock) (s *SourcePacketStats) {
+	s = new(SourcePacketStats)
+	s.Source = source
+	s.Fps = timing.NewFps(timeWindow, clock)
+	s.InterArrival = timing.NewTiming(timeWindow, clock)
+	s.sizes = timing.NewTimeWindow[int](timeWindow)
+	s.timeWindow = timeWindow
+	s.clock = clock
+	return s
+}
+
+// Add adds a received packet
+func (s *PacketStats) Add(source string, received time.Time, size int, truncated bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	sourceStats := s.source(source)
+	sourceStats.Fps.Inc()
+	// receive times of replayed packets are not necessarily in order
+	if received.After(sourceStats.LastReceived) {
+		if !sourceStats.LastReceived.IsZero() {
+			sourceStats.InterArrival.Add(received.Sub(sourceStats.LastReceived))
+		}
+		sourceStats.LastReceived = received
+	}
+	sourceStats.NumPackets++
+	sourceStats.NumBytes += size
+	if truncated {
+		sourceStats.NumTruncated++
+	}
+	sourceStats.sizes.Add(received, size)
+	sourceStats.sizes.Prune(received)
+}
+
+// AddUnmarshalFailure counts a packet of the source that could not be decoded
+func (s *PacketStats) AddUnmarshalFailure(source string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.source(source).NumUnmarshalFailures++
+}
+
+func (s *PacketStats) source(source string) *SourcePacketStats {
+	sourceStats, ok := s.Sources[source]
+	if !ok {
+		sourceStats = NewSourcePacketStats(source, s.timeWindow, s.clock)
+		s.Sources[source] = sourceStats
+	}
+	return sourceStats
+}
+
+// BytesPerSecond returns the data rate within the time window
+func (s *SourcePacketStats) BytesPerSecond() float64 {
+	sum := 0
+	for _, size := range s.sizes.All() {
+		sum += size
+	}
+	return float64(sum) / s.timeWindow.Seconds()
+}
+
+func (s *PacketStats) SortedSources() []string {
+	sources := make([]string, 0, len(s.Sources))
+	for source := range s.Sources {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+func (s *SourcePacketStats) String() string {
+	snapshot := s.Snapshot()
+	return fmt.Sprintf("%v: %5.1f packets/s | %6.1f kB/s | size %v B (max %v B) | jitter %v | max gap %v | %v unmarshal failures | %v truncated",
+		s.Source, snapshot.PacketRate, snapshot.BytesPerSecond/1000, snapshot.Size.Avg, snapshot.Size.Max,
+		snapshot.InterArrival.StdDev, snapshot.InterArrival.Max, s.NumUnmarshalFailures, s.NumTruncated)
+}
+
+func (s *PacketStats) String() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	str := ""
+	for _, source := range s.SortedSources() {
+		str += s.Name + " " + s.Sources[source].String() + "\n"
+	}
+	return str
+}
@@ -0,0 +1,77 @@
+package network
+
+import (
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"testing"
+	"time"
+)
+
+func TestPacketStats_Add(t *testing.T) {
+	clock := timing.NewManualClock(time.Unix(1000, 0))
+	stats := NewPacketStats("vision", time.Second, clock)
+
+	sizes := []int{200, 600, 600, 9000}
+	for i, size := range sizes {
+		stats.Add("10.0.0.1", clock.Now(), size, i == len(sizes)-1)
+		clock.Add(100 * time.Millisecond)
+	}
+	stats.AddUnmarshalFailure("10.0.0.1")
+	stats.Add("10.0.0.2", clock.Now(), 100, false)
+
+	snapshot := stats.Snapshot()
+	if len(snapshot.Sources) != 2 {
+		t.Fatalf("Expected 2 sources, got %v", len(snapshot.Sources))
+	}
+	source := snapshot.Sources[0]
+	if source.Source != "10.0.0.1" || source.NumPackets != 4 || source.NumBytes != 10400 {
+		t.Errorf("Unexpected source statistics: %+v", source)
+	}
+	if source.NumUnmarshalFailures != 1 || source.NumTruncated != 1 {
+		t.Errorf("Expected 1 unmarshal failure and 1 truncated packet, got %v and %v", source.NumUnmarshalFailures, source.NumTruncated)
+	}
+	if source.Size.Min != 200 || source.Size.Max != 9000 || source.Size.Avg != 2600 {
+		t.Errorf("Unexpected size distribution: %+v", source.Size)
+	}
+	expectedCounts := []int{1, 0, 2, 0, 0, 0, 1}
+	for i, count := range expectedCounts {
+		if source.Size.Counts[i] != count {
+			t.Errorf("Expected %v packets in bucket %v, got %v", count, i, source.Size.Counts[i])
+		}
+	}
+	if source.BytesPerSecond != 10400 {
+		t.Errorf("Expected 10400 bytes/s, got %v", source.BytesPerSecond)
+	}
+	if source.InterArrival.NumMeasures != 3 || source.InterArrival.Max != 100*time.Millisecond || source.InterArrival.StdDev != 0 {
+		t.Errorf("Unexpected inter arrival times: %+v", source.InterArrival)
+	}
+
+	clock.Add(2 * time.Second)
+	stats.Add("10.0.0.1", clock.Now(), 300, false)
+	source = stats.Snapshot().Sources[0]
+	if source.Size.Min != 300 || source.Size.Max != 300 {
+		t.Errorf("Expected old packet sizes to be pruned, got %+v", source.Size)
+	}
+}
+
+func TestPacketStats_SilentSource(t *testing.T) {
+	clock := timing.NewManualClock(time.Unix(1000, 0))
+	stats := NewPacketStats("vision", time.Second, clock)
+	for i := 0; i < 10; i++ {
+		stats.Add("10.0.0.1", clock.Now(), 500, false)
+		clock.Add(10 * time.Millisecond)
+	}
+	if source := stats.Snapshot().Sources[0]; source.PacketRate != 10 || source.BytesPerSecond != 5000 {
+		t.Errorf("Expected 10 packets/s and 5000 bytes/s, got %v and %v", source.PacketRate, source.BytesPerSecond)
+	}
+
+	// the source stops sending
+	clock.Add(2 * time.Second)
+	source := stats.Snapshot().Sources[0]
+	if source.PacketRate != 0 || source.BytesPerSecond != 0 || source.Size.Max != 0 {
+		t.Errorf("Expected no packets of a silent source, got %v packets/s, %v bytes/s and size %+v",
+			source.PacketRate, source.BytesPerSecond, source.Size)
+	}
+	if source.NumPackets != 10 {
+		t.Errorf("Expected the total number of packets to be kept, got %v", source.NumPackets)
+	}
+}
@@ -0,0 +1,90 @@
+package network
+
+import (
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"time"
+)
+
+// PacketStatsSnapshot is a copy of the packet statistics of a stream
+type PacketStatsSnapshot struct {
+	Name    string                      `json:"name"`
+	Sources []SourcePacketStatsSnapshot `json:"sources"`
+}
+
+// SourcePacketStatsSnapshot is a copy of the packet statistics of a single source
+type SourcePacketStatsSnapshot struct {
+	Source               string                `json:"source"`
+	PacketRate           float32               `json:"packetRate"`
+	BytesPerSecond       float64               `json:"bytesPerSecond"`
+	Size                 PacketSizeSnapshot    `json:"size"`
+	InterArrival         timing.TimingSnapshot `json:"interArrival"`
+	NumPackets           int                   `json:"numPackets"`
+	NumBytes             int                   `json:"numBytes"`
+	NumUnmarshalFailures int                   `json:"numUnmarshalFailures"`
+	NumTruncated         int                   `json:"numTruncated"`
+	LastReceived         time.Time             `json:"lastReceived"`
+}
+
+// PacketSizeSnapshot is the distribution of packet sizes in bytes within the time window
+type PacketSizeSnapshot struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+	Avg int `json:"avg"`
+	// Bounds are the inclusive upper bounds of the histogram buckets
+	Bounds []int `json:"bounds"`
+	// Counts are the number of packets per bucket, the last entry counts all packets above the last bound
+	Counts []int `json:"counts"`
+}
+
+func (s *PacketStats) Snapshot() (snapshot PacketStatsSnapshot) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	snapshot.Name = s.Name
+	snapshot.Sources = []SourcePacketStatsSnapshot{}
+	for _, source := range s.SortedSources() {
+		snapshot.Sources = append(snapshot.Sources, s.Sources[source].Snapshot())
+	}
+	return
+}
+
+func (s *SourcePacketStats) Snapshot() (snapshot SourcePacketStatsSnapshot) {
+	// a source that stopped sending has no packets within the time window
+	s.sizes.Prune(s.clock.Now())
+	snapshot.Source = s.Source
+	snapshot.PacketRate = s.Fps.Float32()
+	snapshot.BytesPerSecond = s.BytesPerSecond()
+	snapshot.Size = s.sizeSnapshot()
+	snapshot.InterArrival = s.InterArrival.Snapshot()
+	snapshot.NumPackets = s.NumPackets
+	snapshot.NumBytes = s.NumBytes
+	snapshot.NumUnmarshalFailures = s.NumUnmarshalFailures
+	snapshot.NumTruncated = s.NumTruncated
+	snapshot.LastReceived = s.LastReceived
+	return
+}
+
+func (s *SourcePacketStats) sizeSnapshot() (snapshot PacketSizeSnapshot) {
+	snapshot.Bounds = PacketSizeBuckets
+	snapshot.Counts = make([]int, len(PacketSizeBuckets)+1)
+	if s.sizes.Len() == 0 {
+		return
+	}
+	snapshot.Min = s.sizes.At(0)
+	sum := 0
+	for _, size := range s.sizes.All() {
+		if size < snapshot.Min {
+			snapshot.Min = size
+		}
+		if size > snapshot.Max {
+			snapshot.Max = size
+		}
+		sum += size
+		bucket := 0
+		for bucket < len(PacketSizeBuckets) && size > PacketSizeBuckets[bucket] {
+			bucket++
+		}
+		snapshot.Counts[bucket]++
+	}
+	snapshot.Avg = sum / s.sizes.Len()
+	return
+}
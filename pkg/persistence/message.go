@@ -0,0 +1,27 @@
+package persistence
+
+import "time"
+
+type MessageType int32
+
+const (
+	MessageBlank                MessageType = 0
+	MessageUnknown              MessageType = 1
+	MessageSslVision2010        MessageType = 2
+	MessageSslRefbox2013        MessageType = 3
+	MessageSslVision2014        MessageType = 4
+	MessageSslVisionTracker2020 MessageType = 5
+	MessageSslIndex2021         MessageType = 6
+)
+
+// Message is a single entry of a log file
+type Message struct {
+	// Timestamp is the receive time in nanoseconds since epoch
+	Timestamp   int64
+	MessageType MessageType
+	Message     []byte
+}
+
+func (m *Message) Time() time.Time {
+	return time.Unix(0, m.Timestamp)
+}
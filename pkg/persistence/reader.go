@@ -0,0 +1,123 @@
+package persistence
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+const headerName = "SSL_LOG_FILE"
+const supportedVersion = 1
+
+// maxMessageSize limits the size of a single message to detect corrupt files early
+const maxMessageSize = 100 * 1024 * 1024
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Reader reads messages from a log file in the standard SSL log format.
+// Gzip compressed files are detected and decompressed automatically.
+type Reader struct {
+	file    *os.File
+	gzip    *gzip.Reader
+	reader  *bufio.Reader
+	Version int32
+}
+
+func NewReader(filename string) (r *Reader, err error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	r, err = newReader(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	r.file = file
+	return r, nil
+}
+
+func newReader(source io.Reader) (r *Reader, err error) {
+	r = new(Reader)
+	r.reader = bufio.NewReader(source)
+
+	magic, err := r.reader.Peek(len(gzipMagic))
+	if err != nil {
+		return nil, fmt.Errorf("could not read file header: %w", err)
+	}
+	if string(magic) == string(gzipMagic) {
+		r.gzip, err = gzip.NewReader(r.reader)
+		if err != nil {
+			return nil, fmt.Errorf("could not open gzip stream: %w", err)
+		}
+		r.reader = bufio.NewReader(r.gzip)
+	}
+
+	if err := r.readHeader(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Reader) readHeader() error {
+	header := make([]byte, len(headerName))
+	if _, err := io.ReadFull(r.reader, header); err != nil {
+		return fmt.Errorf("could not read file header: %w", err)
+	}
+	if string(header) != headerName {
+		return fmt.Errorf("unexpected file header: %q", header)
+	}
+	if err := binary.Read(r.reader, binary.BigEndian, &r.Version); err != nil {
+		return fmt.Errorf("could not read file version: %w", err)
+	}
+	if r.Version != supportedVersion {
+		return fmt.Errorf("unsupported file version: %d", r.Version)
+	}
+	return nil
+}
+
+// ReadMessage reads the next message from the log file.
+// It returns io.EOF when there are no more messages.
+func (r *Reader) ReadMessage() (*Message, error) {
+	msg := new(Message)
+	if err := binary.Read(r.reader, binary.BigEndian, &msg.Timestamp); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+	if err := binary.Read(r.reader, binary.BigEndian, &msg.MessageType); err != nil {
+		return nil, fmt.Errorf("could not read message type: %w", err)
+	}
+	if msg.MessageType == MessageSslIndex2021 {
+		// The index is always the last message and is followed by trailing data that is not a message
+		return nil, io.EOF
+	}
+	var size int32
+	if err := binary.Read(r.reader, binary.BigEndian, &size); err != nil {
+		return nil, fmt.Errorf("could not read message size: %w", err)
+	}
+	if size < 0 || size > maxMessageSize {
+		return nil, fmt.Errorf("invalid message size: %d", size)
+	}
+	msg.Message = make([]byte, size)
+	if _, err := io.ReadFull(r.reader, msg.Message); err != nil {
+		return nil, fmt.Errorf("could not read message: %w", err)
+	}
+	return msg, nil
+}
+
+func (r *Reader) Close() error {
+	if r.gzip != nil {
+		if err := r.gzip.Close(); err != nil {
+			return err
+		}
+	}
+	if r.file != nil {
+		return r.file.Close()
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package persistence
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"testing"
+)
+
+func writeTestLog(t *testing.T, w io.Writer, messages []Message) {
+	if _, err := w.Write([]byte(headerName)); err != nil {
+		t.Fatal(err)
+	}
+	if err := binary.Write(w, binary.BigEndian, int32(supportedVersion)); err != nil {
+		t.Fatal(err)
+	}
+	for _, msg := range messages {
+		if err := binary.Write(w, binary.BigEndian, msg.Timestamp); err != nil {
+			t.Fatal(err)
+		}
+		if err := binary.Write(w, binary.BigEndian, msg.MessageType); err != nil {
+			t.Fatal(err)
+		}
+		if err := binary.Write(w, binary.BigEndian, int32(len(msg.Message))); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(msg.Message); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func readAll(t *testing.T, reader *Reader) (messages []*Message) {
+	for {
+		msg, err := reader.ReadMessage()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		messages = append(messages, msg)
+	}
+}
+
+var testMessages = []Message{
+	{Timestamp: 1000, MessageType: MessageSslVision2014, Message: []byte{1, 2, 3}},
+	{Timestamp: 2000, MessageType: MessageSslRefbox2013, Message: []byte{}},
+	{Timestamp: 3000, MessageType: MessageSslVision2014, Message: []byte{4}},
+}
+
+func checkMessages(t *testing.T, messages []*Message) {
+	if len(messages) != len(testMessages) {
+		t.Fatalf("Read %v messages, expected %v", len(messages), len(testMessages))
+	}
+	for i, msg := range messages {
+		expected := testMessages[i]
+		if msg.Timestamp != expected.Timestamp ||
+			msg.MessageType != expected.MessageType ||
+			!bytes.Equal(msg.Message, expected.Message) {
+			t.Errorf("Message %v is %v, expected %v", i, *msg, expected)
+		}
+	}
+}
+
+func TestReader_Plain(t *testing.T) {
+	var buf bytes.Buffer
+	writeTestLog(t, &buf, testMessages)
+
+	reader, err := newReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkMessages(t, readAll(t, reader))
+}
+
+func TestReader_Gzip(t *testing.T) {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	writeTestLog(t, gzipWriter, testMessages)
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := newReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkMessages(t, readAll(t, reader))
+}
+
+func TestReader_InvalidHeader(t *testing.T) {
+	if _, err := newReader(bytes.NewBufferString("NOT_A_LOG_FILE")); err == nil {
+		t.Error("Expected an error for an invalid header")
+	}
+}
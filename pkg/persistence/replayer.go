@@ -0,0 +1,47 @@
+package persistence
+
+import (
+	"io"
+	"time"
+)
+
+// Replayer plays back all messages of a log file
+type Replayer struct {
+	reader *Reader
+	// Speed is the replay speed relative to the recording. Zero or less replays as fast as possible.
+	Speed float64
+}
+
+func NewReplayer(reader *Reader, speed float64) (r *Replayer) {
+	r = new(Replayer)
+	r.reader = reader
+	r.Speed = speed
+	return r
+}
+
+// Replay reads all messages and passes them to the consumer, keeping the time between messages according to Speed.
+func (r *Replayer) Replay(consumer func(*Message)) error {
+	var firstMessageTime time.Time
+	var replayStart time.Time
+	for {
+		msg, err := r.reader.ReadMessage()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if r.Speed > 0 {
+			if firstMessageTime.IsZero() {
+				firstMessageTime = msg.Time()
+				replayStart = time.Now()
+			}
+			offset := time.Duration(float64(msg.Time().Sub(firstMessageTime)) / r.Speed)
+			if wait := time.Until(replayStart.Add(offset)); wait > 0 {
+				time.Sleep(wait)
+			}
+		}
+
+		consumer(msg)
+	}
+}
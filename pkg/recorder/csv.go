@@ -0,0 +1,221 @@
+package recorder
+
+import (
+	"bytes"
+	"encoding/csv"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/inspector"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/vision"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// csvWriter writes one row per value with the columns of csvHeader
+type csvWriter struct{}
+
+var csvHeader = []string{"time", "gameState", "kind", "source", "camera", "team", "id", "metric", "value"}
+
+type csvRow struct {
+	kind   string
+	source string
+	camera string
+	team   string
+	id     string
+	metric string
+	value  string
+}
+
+func (csvWriter) header() []byte {
+	data, _ := writeCsv([][]string{csvHeader})
+	return data
+}
+
+func (csvWriter) snapshot(snapshot inspector.Snapshot) ([]byte, error) {
+	var rows []csvRow
+	for _, clock := range snapshot.Clocks {
+		rows = append(rows,
+			csvRow{kind: "clock", source: clock.Host, metric: "offset", value: duration(clock.ClockOffset.Median)},
+			csvRow{kind: "clock", source: clock.Host, metric: "rtt", value: duration(clock.RTT.Median)},
+		)
+	}
+	for _, cam := range snapshot.Vision.Cameras {
+		camera := strconv.Itoa(cam.CameraId)
+		camStart := len(rows)
+		rows = append(rows,
+			csvRow{kind: "camera", camera: camera, metric: "fps", value: float(float64(cam.Frames.Fps))},
+			csvRow{kind: "camera", camera: camera, metric: "quality", value: float(cam.Frames.Quality)},
+			csvRow{kind: "camera", camera: camera, metric: "deltaTime", value: float(cam.Frames.DeltaTime)},
+			csvRow{kind: "camera", camera: camera, metric: "deltaTimeSigma", value: float(cam.Frames.DeltaTimeSigma)},
+			csvRow{kind: "camera", camera: camera, metric: "visibleBlue", value: strconv.Itoa(cam.NumVisibleBlue)},
+			csvRow{kind: "camera", camera: camera, metric: "visibleYellow", value: strconv.Itoa(cam.NumVisibleYellow)},
+			csvRow{kind: "camera", camera: camera, metric: "reprojectionError", value: float(cam.Reprojection.Error.Mean)},
+			csvRow{kind: "camera", camera: camera, metric: "orientationNoise", value: float(cam.OrientationNoise)},
+			csvRow{kind: "camera", camera: camera, metric: "orientationFlips", value: strconv.Itoa(cam.NumOrientationFlips)},
+			csvRow{kind: "camera", camera: camera, metric: "frameGaps", value: strconv.Itoa(cam.Sequence.NumGaps)},
+			csvRow{kind: "camera", camera: camera, metric: "lostFrames", value: strconv.Itoa(cam.Sequence.NumLost)},
+			csvRow{kind: "camera", camera: camera, metric: "duplicateFrames", value: strconv.Itoa(cam.Sequence.NumDuplicates)},
+			csvRow{kind: "camera", camera: camera, metric: "reorderedFrames", value: strconv.Itoa(cam.Sequence.NumReordered)},
+			csvRow{kind: "camera", camera: camera, metric: "frameResets", value: strconv.Itoa(cam.Sequence.NumResets)},
+			csvRow{kind: "camera", camera: camera, metric: "duplicateIds", value: strconv.Itoa(cam.NumDuplicateIds)},
+			csvRow{kind: "camera", camera: camera, metric: "activeDuplicateIds", value: strconv.Itoa(cam.ActiveDuplicateIds)},
+			csvRow{kind: "camera", camera: camera, metric: "idStability", value: float(cam.IdStability)},
+			csvRow{kind: "camera", camera: camera, metric: "idChanges", value: strconv.Itoa(cam.NumIdChanges)},
+			csvRow{kind: "camera", camera: camera, metric: "idFlickers", value: strconv.Itoa(cam.NumIdFlickers)},
+			csvRow{kind: "camera", camera: camera, metric: "idPhantoms", value: strconv.Itoa(cam.NumIdPhantoms)},
+			csvRow{kind: "camera", camera: camera, metric: "ballConfidence", value: float(cam.BallBlobs.Confidence.Mean)},
+			csvRow{kind: "camera", camera: camera, metric: "ballConfidenceMin", value: float(cam.BallBlobs.Confidence.Min)},
+			csvRow{kind: "camera", camera: camera, metric: "ballArea", value: float(cam.BallBlobs.Area.Mean)},
+			csvRow{kind: "camera", camera: camera, metric: "ballAreaRatio", value: float(cam.BallBlobs.AreaRatio.Mean)},
+			csvRow{kind: "camera", camera: camera, metric: "ballConfidenceTrend", value: float(cam.BallBlobs.ConfidenceTrend)},
+			csvRow{kind: "camera", camera: camera, metric: "ballAreaRatioTrend", value: float(cam.BallBlobs.AreaRatioTrend)},
+			csvRow{kind: "camera", camera: camera, metric: "ballHeights", value: strconv.Itoa(cam.BallBlobs.NumHeights)},
+			csvRow{kind: "camera", camera: camera, metric: "stationaryObjects", value: strconv.Itoa(cam.Stationary.NumObjects)},
+			csvRow{kind: "camera", camera: camera, metric: "stationaryNoise", value: float(cam.Stationary.StdDev)},
+			csvRow{kind: "camera", camera: camera, metric: "stationaryMaxDeviation", value: float(cam.Stationary.MaxDeviation)},
+		)
+		rows = append(rows, timingRows(camera, "processing", cam.TimingProcessing)...)
+		rows = append(rows, timingRows(camera, "receiving", cam.TimingReceiving)...)
+		rows = append(rows, timingRows(camera, "captureInterval", cam.Capture.Interval)...)
+		rows = append(rows,
+			csvRow{kind: "camera", camera: camera, metric: "nonMonotonicCaptures", value: strconv.Itoa(cam.Capture.NumNonMonotonic)},
+			csvRow{kind: "camera", camera: camera, metric: "missingCaptures", value: strconv.Itoa(cam.Capture.NumMissingCaptures)},
+			csvRow{kind: "camera", camera: camera, metric: "captureDrift", value: float(cam.Capture.Drift)},
+			csvRow{kind: "camera", camera: camera, metric: "relativeCaptureDrift", value: float(cam.Capture.RelativeDrift)},
+			csvRow{kind: "camera", camera: camera, metric: "relativeCaptureOffset", value: duration(cam.Capture.RelativeOffset)},
+		)
+		for i, ball := range cam.Balls {
+			rows = append(rows, objectRows("ball", camera, "", strconv.Itoa(i), ball)...)
+		}
+		for _, robot := range cam.Robots {
+			rows = append(rows, objectRows("robot", camera, string(robot.Color), strconv.Itoa(robot.Id), robot.ObjectSnapshot)...)
+			rows = append(rows,
+				csvRow{kind: "robot", camera: camera, team: string(robot.Color), id: strconv.Itoa(robot.Id), metric: "orientationNoise", value: float(robot.Orientation.Noise)},
+				csvRow{kind: "robot", camera: camera, team: string(robot.Color), id: strconv.Itoa(robot.Id), metric: "idStability", value: float(robot.Ids.Stability)},
+			)
+		}
+		for i := camStart; i < len(rows); i++ {
+			rows[i].source = cam.Source
+		}
+	}
+	for _, camSources := range snapshot.Vision.CamSources {
+		camera := strconv.Itoa(camSources.CameraId)
+		rows = append(rows,
+			csvRow{kind: "cameraSources", camera: camera, metric: "activeSources", value: strconv.Itoa(len(camSources.Active))},
+			csvRow{kind: "cameraSources", camera: camera, metric: "sourceSwitches", value: strconv.Itoa(camSources.NumSwitches)},
+		)
+	}
+	for _, duplicate := range snapshot.Vision.Duplicates {
+		if !duplicate.Active {
+			continue
+		}
+		var cameras []string
+		for _, camId := range duplicate.CameraIds {
+			cameras = append(cameras, strconv.Itoa(camId))
+		}
+		rows = append(rows, csvRow{kind: "duplicate", camera: strings.Join(cameras, "-"), team: string(duplicate.Color),
+			id: strconv.Itoa(duplicate.Id), metric: "duration", value: duration(duplicate.Duration)})
+	}
+	for _, pair := range snapshot.Vision.CrossCam {
+		cameras := strconv.Itoa(pair.CamA) + "-" + strconv.Itoa(pair.CamB)
+		rows = append(rows,
+			csvRow{kind: "cameraPair", camera: cameras, metric: "robotDistance", value: float(pair.Robots.MeanDistance)},
+			csvRow{kind: "cameraPair", camera: cameras, metric: "ballDistance", value: float(pair.Balls.MeanDistance)},
+		)
+	}
+
+	for _, source := range snapshot.Referee.Sources {
+		rows = append(rows,
+			csvRow{kind: "referee", source: source.SourceIdentifier, metric: "packetRate", value: float(float64(source.PacketRate))},
+			csvRow{kind: "referee", source: source.SourceIdentifier, metric: "counterJumps", value: strconv.Itoa(source.NumCounterJumps)},
+			csvRow{kind: "referee", source: source.SourceIdentifier, metric: "counterResets", value: strconv.Itoa(source.NumCounterResets)},
+		)
+	}
+
+	for _, stream := range snapshot.Network {
+		for _, packets := range stream.Sources {
+			source := stream.Name + "/" + packets.Source
+			rows = append(rows,
+				csvRow{kind: "network", source: source, metric: "packetRate", value: float(float64(packets.PacketRate))},
+				csvRow{kind: "network", source: source, metric: "bytesPerSecond", value: float(packets.BytesPerSecond)},
+				csvRow{kind: "network", source: source, metric: "packetSizeAvg", value: strconv.Itoa(packets.Size.Avg)},
+				csvRow{kind: "network", source: source, metric: "packetSizeMax", value: strconv.Itoa(packets.Size.Max)},
+				csvRow{kind: "network", source: source, metric: "jitter", value: duration(packets.InterArrival.StdDev)},
+				csvRow{kind: "network", source: source, metric: "maxGap", value: duration(packets.InterArrival.Max)},
+				csvRow{kind: "network", source: source, metric: "unmarshalFailures", value: strconv.Itoa(packets.NumUnmarshalFailures)},
+				csvRow{kind: "network", source: source, metric: "truncated", value: strconv.Itoa(packets.NumTruncated)},
+			)
+		}
+	}
+
+	for _, alert := range snapshot.Alerts {
+		rows = append(rows, csvRow{kind: "alert", source: alert.Subject, metric: alert.Rule, value: float(alert.Value)})
+	}
+
+	t := snapshot.Time.Format(time.RFC3339Nano)
+	gameState := string(snapshot.Vision.GameState)
+	records := make([][]string, len(rows))
+	for i, row := range rows {
+		records[i] = []string{t, gameState, row.kind, row.source, row.camera, row.team, row.id, row.metric, row.value}
+	}
+	return writeCsv(records)
+}
+
+// timingRows returns the statistics of a timing with metrics named like the alert metrics, like receiving.p95
+func timingRows(camera string, name string, s timing.TimingSnapshot) []csvRow {
+	rows := []csvRow{
+		{kind: "camera", camera: camera, metric: name + ".median", value: duration(s.Median)},
+		{kind: "camera", camera: camera, metric: name + ".max", value: duration(s.Max)},
+		{kind: "camera", camera: camera, metric: name + ".stdDev", value: duration(s.StdDev)},
+	}
+	for _, p := range s.Percentiles {
+		rows = append(rows, csvRow{kind: "camera", camera: camera, metric: name + "." + p.Name, value: duration(p.Duration)})
+	}
+	return rows
+}
+
+func objectRows(kind string, camera string, team string, id string, object vision.ObjectSnapshot) []csvRow {
+	rows := []csvRow{
+		{kind: kind, camera: camera, team: team, id: id, metric: "quality", value: float(object.Frames.Quality)},
+		{kind: kind, camera: camera, team: team, id: id, metric: "x", value: float(float64(object.Position.X))},
+		{kind: kind, camera: camera, team: team, id: id, metric: "y", value: float(float64(object.Position.Y))},
+	}
+	if object.Blob != nil {
+		rows = append(rows,
+			csvRow{kind: kind, camera: camera, team: team, id: id, metric: "confidence", value: float(object.Blob.Confidence.Mean)},
+			csvRow{kind: kind, camera: camera, team: team, id: id, metric: "area", value: float(object.Blob.Area.Mean)},
+			csvRow{kind: kind, camera: camera, team: team, id: id, metric: "areaRatio", value: float(object.Blob.AreaRatio.Mean)},
+		)
+	}
+	if object.Stationary.Stationary {
+		rows = append(rows,
+			csvRow{kind: kind, camera: camera, team: team, id: id, metric: "stationaryNoise", value: float(object.Stationary.StdDev)},
+			csvRow{kind: kind, camera: camera, team: team, id: id, metric: "stationaryNoiseX", value: float(object.Stationary.StdDevX)},
+			csvRow{kind: kind, camera: camera, team: team, id: id, metric: "stationaryNoiseY", value: float(object.Stationary.StdDevY)},
+			csvRow{kind: kind, camera: camera, team: team, id: id, metric: "stationaryMaxDeviation", value: float(object.Stationary.MaxDeviation)},
+		)
+	}
+	return rows
+}
+
+func (csvWriter) logEntry(t time.Time, entry string) ([]byte, error) {
+	return writeCsv([][]string{{t.Format(time.RFC3339Nano), "", "log", "", "", "", "", "message", entry}})
+}
+
+func writeCsv(records [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func float(value float64) string {
+	return strconv.FormatFloat(value, 'g', 6, 64)
+}
+
+// duration formats a duration in seconds
+func duration(d time.Duration) string {
+	return float(d.Seconds())
+}
@@ -0,0 +1,37 @@
+package recorder
+
+import (
+	"encoding/json"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/inspector"
+	"time"
+)
+
+// jsonLinesWriter writes one JSON object per line
+type jsonLinesWriter struct{}
+
+type jsonRecord struct {
+	Type     string              `json:"type"`
+	Time     time.Time           `json:"time"`
+	Snapshot *inspector.Snapshot `json:"snapshot,omitempty"`
+	Message  string              `json:"message,omitempty"`
+}
+
+func (jsonLinesWriter) header() []byte {
+	return nil
+}
+
+func (jsonLinesWriter) snapshot(snapshot inspector.Snapshot) ([]byte, error) {
+	return jsonLine(jsonRecord{Type: "snapshot", Time: snapshot.Time, Snapshot: &snapshot})
+}
+
+func (jsonLinesWriter) logEntry(t time.Time, entry string) ([]byte, error) {
+	return jsonLine(jsonRecord{Type: "log", Time: t, Message: entry})
+}
+
+func jsonLine(record jsonRecord) ([]byte, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
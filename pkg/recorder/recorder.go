@@ -0,0 +1,162 @@
+package recorder
+
+import (
+	"fmt"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/inspector"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Format is the file format of a recording
+type Format string
+
+const (
+	FormatJsonLines Format = "jsonl"
+	FormatCsv       Format = "csv"
+)
+
+// Recorder periodically writes snapshots of all statistics and new log entries to files
+type Recorder struct {
+	inspector *inspector.Inspector
+	Dir       string
+	Format    Format
+	// Interval is the time between two snapshots
+	Interval time.Duration
+	// MaxFileSize is the size in bytes after which a new file is started, zero to disable
+	MaxFileSize int64
+	// MaxFileAge is the duration after which a new file is started, zero to disable
+	MaxFileAge time.Duration
+
+	writer writer
+	// clock is the time source for file names and the file age
+	clock       timing.Clock
+	file        *os.File
+	fileSize    int64
+	fileCreated time.Time
+	logIndex    int
+}
+
+// writer writes records in a specific format
+type writer interface {
+	header() []byte
+	snapshot(snapshot inspector.Snapshot) ([]byte, error)
+	logEntry(t time.Time, entry string) ([]byte, error)
+}
+
+func NewRecorder(inspector *inspector.Inspector, dir string, format Format) (r *Recorder, err error) {
+	r = new(Recorder)
+	r.inspector = inspector
+	r.Dir = dir
+	r.Format = format
+	r.Interval = time.Second
+	r.clock = timing.WallClock{}
+	switch format {
+	case FormatJsonLines:
+		r.writer = jsonLinesWriter{}
+	case FormatCsv:
+		r.writer = csvWriter{}
+	default:
+		return nil, fmt.Errorf("unknown format: %v", format)
+	}
+	return r, nil
+}
+
+// Record writes snapshots periodically and only returns if the directory can not be created.
+// After an error, the file is closed and a new file is opened with the next snapshot.
+func (r *Recorder) Record() error {
+	if err := os.MkdirAll(r.Dir, 0755); err != nil {
+		return err
+	}
+	defer r.closeFile()
+	for {
+		if err := r.record(); err != nil {
+			log.Println("Could not record statistics: ", err)
+			r.closeFile()
+		}
+		time.Sleep(r.Interval)
+	}
+}
+
+func (r *Recorder) record() error {
+	snapshot := r.inspector.Snapshot()
+	entries, logIndex := r.inspector.Stats.LogEntriesSince(r.logIndex)
+	// log entries are recorded separately
+	snapshot.Vision.Log = nil
+
+	data, err := r.writer.snapshot(snapshot)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		logData, err := r.writer.logEntry(snapshot.Time, entry)
+		if err != nil {
+			return err
+		}
+		data = append(data, logData...)
+	}
+	if err := r.write(data); err != nil {
+		return err
+	}
+	r.logIndex = logIndex
+	return nil
+}
+
+func (r *Recorder) write(data []byte) error {
+	if r.file == nil || r.needsRotation() {
+		if err := r.openFile(); err != nil {
+			return err
+		}
+	}
+	n, err := r.file.Write(data)
+	r.fileSize += int64(n)
+	return err
+}
+
+func (r *Recorder) needsRotation() bool {
+	return (r.MaxFileSize > 0 && r.fileSize >= r.MaxFileSize) ||
+		(r.MaxFileAge > 0 && r.clock.Now().Sub(r.fileCreated) >= r.MaxFileAge)
+}
+
+func (r *Recorder) openFile() error {
+	r.closeFile()
+	r.fileCreated = r.clock.Now()
+	file, filename, err := r.createFile()
+	if err != nil {
+		return err
+	}
+	log.Println("Recording statistics to", filename)
+	r.file = file
+	r.fileSize = 0
+	header := r.writer.header()
+	n, err := r.file.Write(header)
+	r.fileSize += int64(n)
+	return err
+}
+
+// createFile creates a new file named after the creation time, with a counter if the file already exists
+func (r *Recorder) createFile() (file *os.File, filename string, err error) {
+	name := "ssl-quality_" + r.fileCreated.Format("2006-01-02_15-04-05.000")
+	for i := 0; ; i++ {
+		filename = filepath.Join(r.Dir, fmt.Sprintf("%s.%s", name, r.Format))
+		if i > 0 {
+			filename = filepath.Join(r.Dir, fmt.Sprintf("%s_%d.%s", name, i, r.Format))
+		}
+		file, err = os.OpenFile(filename, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if !os.IsExist(err) {
+			return
+		}
+	}
+}
+
+func (r *Recorder) closeFile() {
+	if r.file == nil {
+		return
+	}
+	if err := r.file.Close(); err != nil {
+		log.Println("Could not close recording file: ", err)
+	}
+	r.file = nil
+}
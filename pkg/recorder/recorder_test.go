@@ -0,0 +1,151 @@
+package recorder
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/inspector"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testRecorder(t *testing.T, format Format) (*Recorder, *timing.ManualClock) {
+	manualClock := timing.NewManualClock(time.Unix(1000, 0))
+	r, err := NewRecorder(inspector.NewTestInspector(manualClock), t.TempDir(), format)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.clock = manualClock
+	return r, manualClock
+}
+
+func recordedFiles(t *testing.T, r *Recorder) []string {
+	files, err := filepath.Glob(filepath.Join(r.Dir, "*."+string(r.Format)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestRecorder_Csv(t *testing.T) {
+	r, _ := testRecorder(t, FormatCsv)
+	r.inspector.Stats.Log(time.Unix(1000, 0), "test entry")
+	if err := r.record(); err != nil {
+		t.Fatal(err)
+	}
+	r.closeFile()
+
+	files := recordedFiles(t, r)
+	if len(files) != 1 {
+		t.Fatalf("Expected 1 file, got %v", files)
+	}
+	file, err := os.Open(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = file.Close() }()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) < 2 || records[0][0] != csvHeader[0] || len(records[0]) != len(csvHeader) {
+		t.Fatalf("Expected the header and rows, got %v", records)
+	}
+	last := records[len(records)-1]
+	if last[slices.Index(csvHeader, "kind")] != "log" || !strings.HasSuffix(last[len(last)-1], ": test entry") {
+		t.Errorf("Expected the log entry in the last row, got %v", last)
+	}
+}
+
+func TestRecorder_JsonLines(t *testing.T) {
+	r, _ := testRecorder(t, FormatJsonLines)
+	r.inspector.Stats.Log(time.Unix(1000, 0), "test entry")
+	for i := 0; i < 2; i++ {
+		if err := r.record(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r.closeFile()
+
+	files := recordedFiles(t, r)
+	if len(files) != 1 {
+		t.Fatalf("Expected 1 file, got %v", files)
+	}
+	file, err := os.Open(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = file.Close() }()
+	var types []string
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		var record jsonRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatal(err)
+		}
+		types = append(types, record.Type)
+	}
+	// the log entry is only recorded once
+	if len(types) != 3 || types[0] != "snapshot" || types[1] != "log" || types[2] != "snapshot" {
+		t.Errorf("Expected snapshot, log and snapshot, got %v", types)
+	}
+}
+
+func TestRecorder_RotateBySize(t *testing.T) {
+	r, _ := testRecorder(t, FormatCsv)
+	r.MaxFileSize = 1
+	// all files are created at the same time
+	for i := 0; i < 3; i++ {
+		if err := r.record(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r.closeFile()
+
+	files := recordedFiles(t, r)
+	if len(files) != 3 {
+		t.Fatalf("Expected 3 files, got %v", files)
+	}
+	for _, filename := range files {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		numHeaders := 0
+		for _, record := range records {
+			if record[0] == csvHeader[0] {
+				numHeaders++
+			}
+		}
+		if numHeaders != 1 {
+			t.Errorf("Expected 1 header in %v, got %v", filename, numHeaders)
+		}
+	}
+}
+
+func TestRecorder_RotateByAge(t *testing.T) {
+	r, manualClock := testRecorder(t, FormatJsonLines)
+	r.MaxFileAge = time.Minute
+	for _, d := range []time.Duration{0, 30 * time.Second, 31 * time.Second} {
+		manualClock.Add(d)
+		if err := r.record(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r.closeFile()
+
+	if files := recordedFiles(t, r); len(files) != 2 {
+		t.Errorf("Expected 2 files, got %v", files)
+	}
+}
@@ -0,0 +1,41 @@
+package referee
+
+// GameState is a coarse state of the game, derived from the referee command
+type GameState string
+
+const (
+	GameStateUnknown       GameState = ""
+	GameStateHalt          GameState = "halt"
+	GameStateStop          GameState = "stop"
+	GameStatePrepare       GameState = "prepare"
+	GameStateRunning       GameState = "running"
+	GameStateBallPlacement GameState = "ballPlacement"
+	GameStateTimeout       GameState = "timeout"
+)
+
+// NewGameState returns the game state that corresponds to a referee command
+func NewGameState(command Referee_Command) GameState {
+	switch command {
+	case Referee_HALT:
+		return GameStateHalt
+	case Referee_STOP, Referee_GOAL_YELLOW, Referee_GOAL_BLUE:
+		return GameStateStop
+	case Referee_PREPARE_KICKOFF_YELLOW, Referee_PREPARE_KICKOFF_BLUE,
+		Referee_PREPARE_PENALTY_YELLOW, Referee_PREPARE_PENALTY_BLUE:
+		return GameStatePrepare
+	case Referee_NORMAL_START, Referee_FORCE_START,
+		Referee_DIRECT_FREE_YELLOW, Referee_DIRECT_FREE_BLUE,
+		Referee_INDIRECT_FREE_YELLOW, Referee_INDIRECT_FREE_BLUE:
+		return GameStateRunning
+	case Referee_BALL_PLACEMENT_YELLOW, Referee_BALL_PLACEMENT_BLUE:
+		return GameStateBallPlacement
+	case Referee_TIMEOUT_YELLOW, Referee_TIMEOUT_BLUE:
+		return GameStateTimeout
+	}
+	return GameStateUnknown
+}
+
+// IsRunning returns true, if the ball is in play
+func (s GameState) IsRunning() bool {
+	return s == GameStateRunning
+}
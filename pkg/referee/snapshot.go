@@ -0,0 +1,47 @@
+package referee
+
+import "time"
+
+// StatsSnapshot is a copy of the current referee statistics
+type StatsSnapshot struct {
+	GameState GameState        `json:"gameState"`
+	Command   string           `json:"command"`
+	Stage     string           `json:"stage"`
+	Sources   []SourceSnapshot `json:"sources"`
+}
+
+type SourceSnapshot struct {
+	SourceIdentifier string    `json:"sourceIdentifier"`
+	PacketRate       float32   `json:"packetRate"`
+	Command          string    `json:"command"`
+	Stage            string    `json:"stage"`
+	CommandCounter   uint32    `json:"commandCounter"`
+	NumPackets       int       `json:"numPackets"`
+	NumCounterJumps  int       `json:"numCounterJumps"`
+	NumCounterResets int       `json:"numCounterResets"`
+	LastReceived     time.Time `json:"lastReceived"`
+}
+
+func (s *Stats) Snapshot() (snapshot StatsSnapshot) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	snapshot.GameState = s.GameState
+	snapshot.Command = s.Command.String()
+	snapshot.Stage = s.Stage.String()
+	snapshot.Sources = []SourceSnapshot{}
+	for _, sourceId := range s.SortedSources() {
+		source := s.Sources[sourceId]
+		snapshot.Sources = append(snapshot.Sources, SourceSnapshot{
+			SourceIdentifier: source.SourceIdentifier,
+			PacketRate:       source.Fps.Float32(),
+			Command:          source.Command.String(),
+			Stage:            source.Stage.String(),
+			CommandCounter:   source.CommandCounter,
+			NumPackets:       source.NumPackets,
+			NumCounterJumps:  source.NumCounterJumps,
+			NumCounterResets: source.NumCounterResets,
+			LastReceived:     source.LastReceived,
+		})
+	}
+	return
+}
@@ -0,0 +1,145 @@
+package referee
+
+import (
+	"fmt"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Stats collects statistics of the referee messages of all game controller instances
+type Stats struct {
+	Sources    map[string]*SourceStats
+	Command    Referee_Command
+	Stage      Referee_Stage
+	GameState  GameState
+	timeWindow time.Duration
+	clock      timing.Clock
+	Mutex      sync.Mutex
+}
+
+// SourceStats collects statistics of a single game controller instance, identified by its source identifier
+type SourceStats struct {
+	SourceIdentifier string
+	Fps              *timing.Fps
+	Command          Referee_Command
+	Stage            Referee_Stage
+	CommandCounter   uint32
+	NumPackets       int
+	// NumCounterJumps is the number of times the command counter increased by more than one
+	NumCounterJumps int
+	// NumCounterResets is the number of times the command counter decreased
+	NumCounterResets int
+	LastReceived     time.Time
+}
+
+func NewStats(timeWindow time.Duration, clock timing.Clock) (s *Stats) {
+	s = new(Stats)
+	s.Sources = map[string]*SourceStats{}
+	s.timeWindow = timeWindow
+	s.clock = clock
+	return s
+}
+
+func NewSourceStats(sourceIdentifier string, timeWindow time.Duration, clock timing.Clock) (s *SourceStats) {
+	s = new(SourceStats)
+	s.SourceIdentifier = sourceIdentifier
+	s.Fps = timing.NewFps(timeWindow, clock)
+	return s
+}
+
+// Process processes a referee message and returns descriptions of noteworthy events
+func (s *Stats) Process(referee *Referee) (events []string) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	tReceived := s.clock.Now()
+	sourceId := referee.GetSourceIdentifier()
+	source, ok := s.Sources[sourceId]
+	if !ok {
+		source = NewSourceStats(sourceId, s.timeWindow, s.clock)
+		s.Sources[sourceId] = source
+		events = append(events, fmt.Sprintf("New game controller source: %v", sourceName(sourceId)))
+	} else {
+		events = append(events, source.checkCounter(referee.GetCommandCounter())...)
+	}
+
+	source.Fps.Inc()
+	source.NumPackets++
+	source.LastReceived = tReceived
+	source.CommandCounter = referee.GetCommandCounter()
+	source.Command = referee.GetCommand()
+	source.Stage = referee.GetStage()
+	if !ok {
+		if active := s.ActiveSources(); len(active) > 1 {
+			events = append(events, fmt.Sprintf("Multiple active game controller sources: %v", active))
+		}
+	}
+
+	if referee.GetStage() != s.Stage {
+		events = append(events, fmt.Sprintf("Stage changed from %v to %v", s.Stage, referee.GetStage()))
+	}
+	if referee.GetCommand() != s.Command || s.GameState == GameStateUnknown {
+		events = append(events, fmt.Sprintf("Command changed to %v", referee.GetCommand()))
+	}
+	s.Command = referee.GetCommand()
+	s.Stage = referee.GetStage()
+	s.GameState = NewGameState(s.Command)
+	return
+}
+
+func (s *SourceStats) checkCounter(counter uint32) (events []string) {
+	if counter < s.CommandCounter {
+		s.NumCounterResets++
+		events = append(events, fmt.Sprintf("Command counter of %v decreased from %v to %v",
+			sourceName(s.SourceIdentifier), s.CommandCounter, counter))
+	} else if counter > s.CommandCounter+1 {
+		s.NumCounterJumps++
+		events = append(events, fmt.Sprintf("Command counter of %v jumped from %v to %v",
+			sourceName(s.SourceIdentifier), s.CommandCounter, counter))
+	}
+	return
+}
+
+// ActiveSources returns the identifiers of all sources that sent a message within the time window
+func (s *Stats) ActiveSources() []string {
+	tOldest := s.clock.Now().Add(-s.timeWindow)
+	var active []string
+	for _, sourceId := range s.SortedSources() {
+		if !s.Sources[sourceId].LastReceived.Before(tOldest) {
+			active = append(active, sourceName(sourceId))
+		}
+	}
+	return active
+}
+
+func (s *Stats) SortedSources() []string {
+	sources := make([]string, 0, len(s.Sources))
+	for sourceId := range s.Sources {
+		sources = append(sources, sourceId)
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+func sourceName(sourceId string) string {
+	if sourceId == "" {
+		return "<unknown>"
+	}
+	return sourceId
+}
+
+func (s *SourceStats) String() string {
+	return fmt.Sprintf("%v: %5.1f packets/s | %v | %v | counter %v | %v jumps | %v resets",
+		sourceName(s.SourceIdentifier), s.Fps.Float32(), s.Stage, s.Command, s.CommandCounter,
+		s.NumCounterJumps, s.NumCounterResets)
+}
+
+func (s *Stats) String() string {
+	str := fmt.Sprintf("Game state: %v (%v, %v)\n", s.GameState, s.Stage, s.Command)
+	for _, sourceId := range s.SortedSources() {
+		str += s.Sources[sourceId].String() + "\n"
+	}
+	return str
+}
@@ -0,0 +1,43 @@
+package referee
+
+import (
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"google.golang.org/protobuf/proto"
+	"testing"
+	"time"
+)
+
+func newReferee(sourceId string, command Referee_Command, counter uint32) *Referee {
+	return &Referee{
+		SourceIdentifier: proto.String(sourceId),
+		Stage:            Referee_NORMAL_FIRST_HALF.Enum(),
+		Command:          command.Enum(),
+		CommandCounter:   proto.Uint32(counter),
+	}
+}
+
+func TestStats_Process(t *testing.T) {
+	clock := timing.NewManualClock(time.Unix(1000, 0))
+	stats := NewStats(time.Second, clock)
+
+	stats.Process(newReferee("gc1", Referee_STOP, 5))
+	clock.Add(100 * time.Millisecond)
+	stats.Process(newReferee("gc1", Referee_FORCE_START, 6))
+	if stats.GameState != GameStateRunning {
+		t.Errorf("Expected game state %v, got %v", GameStateRunning, stats.GameState)
+	}
+
+	clock.Add(100 * time.Millisecond)
+	stats.Process(newReferee("gc1", Referee_STOP, 8))
+	clock.Add(100 * time.Millisecond)
+	stats.Process(newReferee("gc1", Referee_HALT, 2))
+	source := stats.Sources["gc1"]
+	if source.NumCounterJumps != 1 || source.NumCounterResets != 1 {
+		t.Errorf("Expected 1 jump and 1 reset, got %v jumps and %v resets", source.NumCounterJumps, source.NumCounterResets)
+	}
+
+	events := stats.Process(newReferee("gc2", Referee_HALT, 0))
+	if len(events) != 2 {
+		t.Errorf("Expected a new source and a duplicate source event, got %v", events)
+	}
+}
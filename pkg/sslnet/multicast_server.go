@@ -9,16 +9,25 @@ import (
 
 const maxDatagramSize = 8192
 
+// Packet holds the meta data of a received datagram
+type Packet struct {
+	Source   *net.UDPAddr
+	Received time.Time
+	Size     int
+	// Truncated is true, if the datagram filled the whole receive buffer and was probably cut off
+	Truncated bool
+}
+
 type MulticastServer struct {
 	connection     *net.UDPConn
 	running        bool
-	consumer       func([]byte)
+	consumer       func([]byte, Packet)
 	mutex          sync.Mutex
 	SkipInterfaces []string
 	Verbose        bool
 }
 
-func NewMulticastServer(consumer func([]byte)) (r *MulticastServer) {
+func NewMulticastServer(consumer func([]byte, Packet)) (r *MulticastServer) {
 	r = new(MulticastServer)
 	r.consumer = consumer
 	return
@@ -111,7 +120,8 @@ func (r *MulticastServer) receiveOnInterface(multicastAddress string, ifi net.In
 		if err := r.connection.SetDeadline(time.Now().Add(300 * time.Millisecond)); err != nil {
 			log.Println("Could not set deadline on connection: ", err)
 		}
-		n, _, err := r.connection.ReadFromUDP(data)
+		n, source, err := r.connection.ReadFromUDP(data)
+		received := time.Now()
 		if err != nil {
 			if r.Verbose {
 				log.Println("ReadFromUDP failed:", err)
@@ -124,7 +134,7 @@ func (r *MulticastServer) receiveOnInterface(multicastAddress string, ifi net.In
 			first = false
 		}
 
-		r.consumer(data[:n])
+		r.consumer(data[:n], Packet{Source: source, Received: received, Size: n, Truncated: n >= len(data)})
 	}
 
 	if r.Verbose {
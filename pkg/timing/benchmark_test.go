@@ -0,0 +1,56 @@
+package timing
+
+import (
+	"testing"
+	"time"
+)
+
+// frameInterval is the time between two frames of a camera running at 75 Hz
+const frameInterval = time.Second / 75
+
+func BenchmarkTiming_Add(b *testing.B) {
+	clock := NewManualClock(time.Unix(0, 0))
+	timing := NewTiming(time.Second*5, clock)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		clock.Add(frameInterval)
+		timing.Add(time.Duration(i%1000) * time.Microsecond)
+	}
+}
+
+func BenchmarkTiming_Snapshot(b *testing.B) {
+	clock := NewManualClock(time.Unix(0, 0))
+	timing := NewTiming(time.Second*5, clock)
+	for i := 0; i < 375; i++ {
+		clock.Add(frameInterval)
+		timing.Add(time.Duration(i%1000) * time.Microsecond)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		timing.Snapshot()
+	}
+}
+
+func BenchmarkFps_Inc(b *testing.B) {
+	clock := NewManualClock(time.Unix(0, 0))
+	fps := NewFps(time.Second*5, clock)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		clock.Add(frameInterval)
+		fps.Inc()
+	}
+}
+
+// BenchmarkFrameStats_Add simulates the per frame usage by an object: add a frame, prune and query the quality
+func BenchmarkFrameStats_Add(b *testing.B) {
+	clock := NewManualClock(time.Unix(0, 0))
+	stats := NewFrameStats(time.Second*5, clock)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		clock.Add(frameInterval)
+		t := clock.Now()
+		stats.Add(uint32(i), t)
+		stats.Prune(t.Add(-time.Millisecond * 500))
+		stats.Quality()
+	}
+}
@@ -0,0 +1,55 @@
+package timing
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a source for the current time
+type Clock interface {
+	Now() time.Time
+}
+
+// WallClock returns the current system time
+type WallClock struct{}
+
+func (WallClock) Now() time.Time {
+	return time.Now()
+}
+
+// ManualClock returns a time that is set explicitly, for example from a replayed log file or in tests
+type ManualClock struct {
+	now   time.Time
+	mutex sync.Mutex
+}
+
+func NewManualClock(now time.Time) (c *ManualClock) {
+	c = new(ManualClock)
+	c.now = now
+	return c
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *ManualClock) Set(now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = now
+}
+
+func (c *ManualClock) Add(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// UnixTime converts seconds since epoch, as sent in SSL messages, to a time
+func UnixTime(seconds float64) time.Time {
+	sec := int64(seconds)
+	ns := int64((seconds - float64(sec)) * 1e9)
+	return time.Unix(sec, ns)
+}
@@ -5,12 +5,25 @@ import (
 	"math"
 )
 
-func colorizePercent(value float64) string {
+// QualityThresholds separate bad, medium and good quality values, which are shown in red, yellow and green
+type QualityThresholds struct {
+	// Low is the threshold below which a quality is bad
+	Low float64
+	// High is the threshold below which a quality is medium
+	High float64
+}
+
+// DefaultQualityThresholds returns the thresholds that are used if none are configured
+func DefaultQualityThresholds() QualityThresholds {
+	return QualityThresholds{Low: 0.3, High: 0.6}
+}
+
+func (t QualityThresholds) colorizePercent(value float64) string {
 	var color int
-	if value < 0.3 {
+	if value < t.Low {
 		// Red
 		color = 31
-	} else if value < 0.6 {
+	} else if value < t.High {
 		// Yellow
 		color = 33
 	} else {
@@ -7,43 +7,37 @@ import (
 
 type Fps struct {
 	timeWindow time.Duration
-	durations  map[time.Time]struct{}
-	mutex      sync.Mutex
+	// times holds the time of each increment within the time window
+	times *TimeWindow[struct{}]
+	clock Clock
+	mutex sync.Mutex
 }
 
-func NewFps(timeWindow time.Duration) (t *Fps) {
+func NewFps(timeWindow time.Duration, clock Clock) (t *Fps) {
 	t = new(Fps)
 	t.timeWindow = timeWindow
-	t.durations = map[time.Time]struct{}{}
+	t.clock = clock
+	t.times = NewTimeWindow[struct{}](timeWindow)
 	return t
 }
 
 func (f *Fps) Inc() {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
-	now := time.Now()
-	f.durations[now] = struct{}{}
-	f.prune(now)
-}
-
-func (f *Fps) prune(now time.Time) {
-	lastValidMeasureTime := now.Add(-f.timeWindow)
-	for measuredTime := range f.durations {
-		if measuredTime.Before(lastValidMeasureTime) {
-			delete(f.durations, measuredTime)
-		}
-	}
+	now := f.clock.Now()
+	f.times.Add(now, struct{}{})
+	f.times.Prune(now)
 }
 
 func (f *Fps) Clear() {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
-	f.durations = map[time.Time]struct{}{}
+	f.times.Clear()
 }
 
 func (f *Fps) Float32() float32 {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
-	numDurations := len(f.durations)
-	return float32(numDurations) / float32(f.timeWindow.Seconds())
+	f.times.Prune(f.clock.Now())
+	return float32(f.times.Len()) / float32(f.timeWindow.Seconds())
 }
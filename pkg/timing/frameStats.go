@@ -8,93 +8,177 @@ import (
 )
 
 type FrameStats struct {
-	Fps        *Fps
-	frames     map[uint32]time.Time
-	mutex      sync.Mutex
-	lastTime   *time.Time
-	deltaTimes map[uint32]time.Duration
+	Fps *Fps
+	// frames holds all frames of the time window in the order they were added, which is expected to be chronological
+	frames   Ring[frame]
+	mutex    sync.Mutex
+	lastTime time.Time
+	// minFrameIds and maxFrameIds are monotonic queues for the minimum and maximum frame id in the time window
+	minFrameIds Ring[frame]
+	maxFrameIds Ring[frame]
+	nextSeq     uint64
+	// lastFrameId is the last added frame number, which is unwrapped to frame.id to handle wrap arounds
+	lastFrameId uint32
+	// numDeltaTimes, deltaTimeSum and deltaTimeSqSum aggregate the time to the previous frame of all frames in seconds
+	numDeltaTimes  int
+	deltaTimeSum   float64
+	deltaTimeSqSum float64
+	// numRemoved counts the removed frames since the delta time sums were last recalculated
+	numRemoved int
+	// QualityThresholds define the color of the quality in String
+	QualityThresholds QualityThresholds
 }
 
-func NewFrameStats(timeWindow time.Duration) (s *FrameStats) {
+type frame struct {
+	// seq identifies the frame within the monotonic queues
+	seq      uint64
+	id       int64
+	t        time.Time
+	hasDelta bool
+	delta    float64
+}
+
+func NewFrameStats(timeWindow time.Duration, clock Clock) (s *FrameStats) {
 	s = new(FrameStats)
-	s.Fps = NewFps(timeWindow)
-	s.frames = map[uint32]time.Time{}
-	s.deltaTimes = map[uint32]time.Duration{}
+	s.Fps = NewFps(timeWindow, clock)
+	s.QualityThresholds = DefaultQualityThresholds()
 	return s
 }
 
 func (s *FrameStats) Add(frameId uint32, t time.Time) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	s.frames[frameId] = t
 	s.Fps.Inc()
-	if s.lastTime != nil {
-		s.deltaTimes[frameId] = t.Sub(*s.lastTime)
+	id := int64(frameId)
+	if s.frames.Len() > 0 {
+		// the signed difference is correct across a wrap around of the frame number
+		diff := int64(int32(frameId - s.lastFrameId))
+		if diff > maxFrameGap || diff < -maxReorderDistance {
+			// the frame number was reset, for example by a restart of ssl-vision
+			s.clearFrames()
+		} else {
+			id = s.frames.Back().id + diff
+		}
+	}
+	s.lastFrameId = frameId
+	if s.frames.Len() > 0 && s.frames.Back().id == id {
+		// the same frame was added again, only update its time
+		last := s.frames.Back()
+		last.t = t
+		s.frames.SetBack(last)
+		s.lastTime = t
+		return
 	}
-	s.lastTime = &t
+
+	f := frame{seq: s.nextSeq, id: id, t: t}
+	s.nextSeq++
+	if !s.lastTime.IsZero() {
+		f.hasDelta = true
+		f.delta = t.Sub(s.lastTime).Seconds()
+		s.numDeltaTimes++
+		s.deltaTimeSum += f.delta
+		s.deltaTimeSqSum += f.delta * f.delta
+	}
+	s.lastTime = t
+	s.frames.PushBack(f)
+
+	for s.minFrameIds.Len() > 0 && s.minFrameIds.Back().id >= id {
+		s.minFrameIds.PopBack()
+	}
+	s.minFrameIds.PushBack(f)
+	for s.maxFrameIds.Len() > 0 && s.maxFrameIds.Back().id <= id {
+		s.maxFrameIds.PopBack()
+	}
+	s.maxFrameIds.PushBack(f)
 }
 
 func (s *FrameStats) Prune(to time.Time) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	for frameId, t := range s.frames {
-		if t.Before(to) {
-			delete(s.frames, frameId)
-			delete(s.deltaTimes, frameId)
+	for s.frames.Len() > 0 && s.frames.Front().t.Before(to) {
+		f := s.frames.PopFront()
+		if f.hasDelta {
+			s.numDeltaTimes--
+			s.deltaTimeSum -= f.delta
+			s.deltaTimeSqSum -= f.delta * f.delta
+		}
+		if s.minFrameIds.Front().seq == f.seq {
+			s.minFrameIds.PopFront()
 		}
+		if s.maxFrameIds.Front().seq == f.seq {
+			s.maxFrameIds.PopFront()
+		}
+		s.numRemoved++
+	}
+	if s.numRemoved >= s.frames.Len() {
+		s.recalculateDeltaTimes()
 	}
 }
 
+// recalculateDeltaTimes sums up the delta times of all frames again,
+// because the incremental sums accumulate rounding errors over time
+func (s *FrameStats) recalculateDeltaTimes() {
+	s.numDeltaTimes = 0
+	s.deltaTimeSum = 0
+	s.deltaTimeSqSum = 0
+	for i := 0; i < s.frames.Len(); i++ {
+		if f := s.frames.At(i); f.hasDelta {
+			s.numDeltaTimes++
+			s.deltaTimeSum += f.delta
+			s.deltaTimeSqSum += f.delta * f.delta
+		}
+	}
+	s.numRemoved = 0
+}
+
 func (s *FrameStats) Clear() {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	s.frames = map[uint32]time.Time{}
-	s.deltaTimes = map[uint32]time.Duration{}
+	s.clearFrames()
 	s.Fps.Clear()
 }
 
+func (s *FrameStats) clearFrames() {
+	s.frames.Clear()
+	s.minFrameIds.Clear()
+	s.maxFrameIds.Clear()
+	s.numDeltaTimes = 0
+	s.deltaTimeSum = 0
+	s.deltaTimeSqSum = 0
+	s.numRemoved = 0
+}
+
 func (s *FrameStats) Quality() float64 {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	min := uint32(math.MaxUint32)
-	max := uint32(0)
-	for frameId := range s.frames {
-		if frameId < min {
-			min = frameId
-		}
-		if frameId > max {
-			max = frameId
-		}
+	if s.frames.Len() == 0 {
+		return 0
 	}
-	sum := float64(len(s.frames))
-	return sum / float64(max-min+1)
+	min := s.minFrameIds.Front().id
+	max := s.maxFrameIds.Front().id
+	// frames that are received again after other frames are counted twice, so limit the quality to 1
+	return math.Min(1, float64(s.frames.Len())/float64(max-min+1))
 }
 
 func (s *FrameStats) DeltaTime() (mu float64, stdDev float64) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	var sum time.Duration
-	for _, dt := range s.deltaTimes {
-		sum += dt
-	}
-	mu = sum.Seconds() / float64(len(s.deltaTimes))
-
-	var sqSum float64
-	for _, dt := range s.deltaTimes {
-		diff := mu - dt.Seconds()
-		sqSum += diff * diff
-	}
-	stdDev = math.Sqrt(sqSum / float64(len(s.deltaTimes)))
+	n := float64(s.numDeltaTimes)
+	mu = s.deltaTimeSum / n
+	// rounding errors of the incremental sums can lead to slightly negative values
+	stdDev = math.Sqrt(math.Max(0, s.deltaTimeSqSum/n-mu*mu))
 	return
 }
 
 func (s *FrameStats) NumFrames() int {
-	return len(s.frames)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.frames.Len()
 }
 
 func (s *FrameStats) String() string {
 	fps := s.Fps.Float32()
 	quality := s.Quality()
 	dt, dtStdDev := s.DeltaTime()
-	return fmt.Sprintf("%v @ %3.0f fps | Δ %.1fms σ %.3f", colorizePercent(quality), fps, dt*1000, dtStdDev*1000)
+	return fmt.Sprintf("%v @ %3.0f fps | Δ %.1fms σ %.3f", s.QualityThresholds.colorizePercent(quality), fps, dt*1000, dtStdDev*1000)
 }
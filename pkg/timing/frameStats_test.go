@@ -8,7 +8,7 @@ import (
 
 func TestFrameStats_Add(t *testing.T) {
 
-	stats := NewFrameStats(time.Millisecond * 500)
+	stats := NewFrameStats(time.Millisecond*500, WallClock{})
 	if math.Abs(stats.Quality()) > 0 {
 		t.Errorf("Quality %v != 0.0 with zero samples", stats.Quality())
 	}
@@ -43,3 +43,61 @@ func TestFrameStats_Add(t *testing.T) {
 		t.Errorf("Quality %v != 2/3 after pruning and deleting oldest sample", stats.Quality())
 	}
 }
+
+func TestFrameStats_RestartAndDuplicates(t *testing.T) {
+	stats := NewFrameStats(time.Second, WallClock{})
+	tStart := time.Now()
+	stats.Add(100, tStart)
+	stats.Add(101, tStart.Add(time.Millisecond*10))
+	stats.Add(101, tStart.Add(time.Millisecond*10))
+	if stats.NumFrames() != 2 || stats.Quality() != 1 {
+		t.Errorf("Duplicate frame counted: %v frames, quality %v", stats.NumFrames(), stats.Quality())
+	}
+
+	// frame numbers restart, e.g. after ssl-vision was restarted
+	stats.Add(0, tStart.Add(time.Millisecond*20))
+	stats.Add(1, tStart.Add(time.Millisecond*30))
+	stats.Prune(tStart.Add(time.Millisecond * 15))
+	if stats.NumFrames() != 2 || stats.Quality() != 1 {
+		t.Errorf("Unexpected state after restart: %v frames, quality %v", stats.NumFrames(), stats.Quality())
+	}
+	if dt, sigma := stats.DeltaTime(); math.Abs(dt-0.01) > 1e-9 || sigma > 1e-6 {
+		t.Errorf("Unexpected delta time %v σ %v", dt, sigma)
+	}
+}
+
+func TestFrameStats_ReceivedAgain(t *testing.T) {
+	stats := NewFrameStats(time.Second, WallClock{})
+	tStart := time.Now()
+	stats.Add(1, tStart)
+	stats.Add(2, tStart.Add(time.Millisecond*10))
+	stats.Add(1, tStart.Add(time.Millisecond*20))
+	if stats.Quality() != 1 {
+		t.Errorf("Quality %v != 1 with a frame received again", stats.Quality())
+	}
+
+	// the delta time sums start from scratch once all frames left the time window
+	stats.Prune(tStart.Add(time.Second))
+	stats.Add(3, tStart.Add(time.Second+time.Millisecond*20))
+	if dt, sigma := stats.DeltaTime(); math.Abs(dt-1) > 1e-9 || sigma > 1e-6 {
+		t.Errorf("Unexpected delta time %v σ %v", dt, sigma)
+	}
+}
+
+func TestFrameStats_WrapAndReset(t *testing.T) {
+	stats := NewFrameStats(time.Second, WallClock{})
+	tStart := time.Now()
+	stats.Add(math.MaxUint32-1, tStart)
+	stats.Add(math.MaxUint32, tStart.Add(time.Millisecond*10))
+	stats.Add(1, tStart.Add(time.Millisecond*20))
+	if math.Abs(stats.Quality()-0.75) > 1e-10 {
+		t.Errorf("Quality %v != 0.75 with 3 out of 4 samples across a wrap around", stats.Quality())
+	}
+
+	stats.Add(5, tStart.Add(time.Millisecond*30))
+	stats.Add(6, tStart.Add(time.Millisecond*40))
+	stats.Add(100000, tStart.Add(time.Millisecond*50))
+	if stats.Quality() < 1 || stats.NumFrames() != 1 {
+		t.Errorf("Quality %v != 1.0 with %v frames after a reset", stats.Quality(), stats.NumFrames())
+	}
+}
@@ -0,0 +1,55 @@
+package timing
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Histogram is the distribution of durations over fixed buckets
+type Histogram struct {
+	// Bounds are the inclusive upper bounds of the buckets in nanoseconds
+	Bounds []time.Duration `json:"bounds"`
+	// Counts are the number of durations per bucket, the last entry counts all durations above the last bound
+	Counts []int `json:"counts"`
+}
+
+// ParseDurations parses a comma separated list of durations, like 1ms,2ms,5ms
+func ParseDurations(str string) ([]time.Duration, error) {
+	var durations []time.Duration
+	for _, field := range strings.Split(str, ",") {
+		d, err := time.ParseDuration(strings.TrimSpace(field))
+		if err != nil {
+			return nil, err
+		}
+		durations = append(durations, d)
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	return durations, nil
+}
+
+func newHistogram(sortedDurations []time.Duration, bounds []time.Duration) (h Histogram) {
+	h.Bounds = bounds
+	h.Counts = make([]int, len(bounds)+1)
+	bucket := 0
+	for _, d := range sortedDurations {
+		for bucket < len(h.Bounds) && d > h.Bounds[bucket] {
+			bucket++
+		}
+		h.Counts[bucket]++
+	}
+	return
+}
+
+func (h Histogram) String() string {
+	var parts []string
+	for i, count := range h.Counts {
+		if i < len(h.Bounds) {
+			parts = append(parts, fmt.Sprintf("≤%v: %d", h.Bounds[i], count))
+		} else if len(h.Bounds) > 0 {
+			parts = append(parts, fmt.Sprintf(">%v: %d", h.Bounds[len(h.Bounds)-1], count))
+		}
+	}
+	return strings.Join(parts, " | ")
+}
@@ -0,0 +1,43 @@
+package timing
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PercentileName returns the name of a percentile, like p95 for 0.95
+func PercentileName(p float64) string {
+	return "p" + strconv.FormatFloat(p*100, 'f', -1, 64)
+}
+
+// ParsePercentiles parses a comma separated list of percentiles, like 0.9,0.95,0.99
+func ParsePercentiles(str string) ([]float64, error) {
+	var percentiles []float64
+	for _, field := range strings.Split(str, ",") {
+		p, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return nil, err
+		}
+		if p <= 0 || p > 1 {
+			return nil, fmt.Errorf("percentile %v is not within (0, 1]", p)
+		}
+		percentiles = append(percentiles, p)
+	}
+	return percentiles, nil
+}
+
+func percentile(sortedDurations []time.Duration, p float64) time.Duration {
+	if len(sortedDurations) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p*float64(len(sortedDurations)))) - 1
+	if i < 0 {
+		i = 0
+	} else if i >= len(sortedDurations) {
+		i = len(sortedDurations) - 1
+	}
+	return sortedDurations[i]
+}
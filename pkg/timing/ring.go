@@ -0,0 +1,68 @@
+package timing
+
+// Ring is a FIFO queue backed by a growing circular buffer
+type Ring[T any] struct {
+	buf  []T
+	head int
+	size int
+}
+
+func (r *Ring[T]) Len() int {
+	return r.size
+}
+
+// At returns the i-th oldest element
+func (r *Ring[T]) At(i int) T {
+	return r.buf[(r.head+i)%len(r.buf)]
+}
+
+func (r *Ring[T]) Front() T {
+	return r.At(0)
+}
+
+func (r *Ring[T]) Back() T {
+	return r.At(r.size - 1)
+}
+
+func (r *Ring[T]) SetBack(v T) {
+	r.buf[(r.head+r.size-1)%len(r.buf)] = v
+}
+
+func (r *Ring[T]) PushBack(v T) {
+	if r.size == len(r.buf) {
+		r.grow()
+	}
+	r.buf[(r.head+r.size)%len(r.buf)] = v
+	r.size++
+}
+
+func (r *Ring[T]) PopFront() T {
+	v := r.buf[r.head]
+	r.head = (r.head + 1) % len(r.buf)
+	r.size--
+	return v
+}
+
+func (r *Ring[T]) PopBack() T {
+	v := r.Back()
+	r.size--
+	return v
+}
+
+func (r *Ring[T]) Clear() {
+	r.head = 0
+	r.size = 0
+}
+
+func (r *Ring[T]) grow() {
+	newSize := 2 * len(r.buf)
+	if newSize == 0 {
+		newSize = 16
+	}
+	buf := make([]T, newSize)
+	for i := 0; i < r.size; i++ {
+		buf[i] = r.At(i)
+	}
+	r.buf = buf
+	r.head = 0
+}
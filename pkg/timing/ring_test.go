@@ -0,0 +1,30 @@
+package timing
+
+import "testing"
+
+func TestRing(t *testing.T) {
+	var r Ring[int]
+	for i := 0; i < 20; i++ {
+		r.PushBack(i)
+	}
+	for i := 0; i < 10; i++ {
+		if v := r.PopFront(); v != i {
+			t.Fatalf("popFront %v != %v", v, i)
+		}
+	}
+	// wrap around the end of the buffer and grow again
+	for i := 20; i < 40; i++ {
+		r.PushBack(i)
+	}
+	if r.Len() != 30 || r.Front() != 10 || r.Back() != 39 {
+		t.Errorf("Unexpected ring state: len %v, front %v, back %v", r.Len(), r.Front(), r.Back())
+	}
+	for i := 0; i < r.Len(); i++ {
+		if r.At(i) != i+10 {
+			t.Errorf("at(%v) %v != %v", i, r.At(i), i+10)
+		}
+	}
+	if v := r.PopBack(); v != 39 || r.Back() != 38 {
+		t.Errorf("popBack %v != 39", v)
+	}
+}
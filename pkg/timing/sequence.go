@@ -0,0 +1,105 @@
+package timing
+
+import "fmt"
+
+// FrameOrder classifies a frame number relative to the previously received frame numbers
+type FrameOrder string
+
+const (
+	FrameInOrder   FrameOrder = "inOrder"
+	FrameGap       FrameOrder = "gap"
+	FrameDuplicate FrameOrder = "duplicate"
+	FrameReordered FrameOrder = "reordered"
+	FrameReset     FrameOrder = "reset"
+)
+
+// maxFrameGap is the largest increase of the frame number that is counted as lost frames instead of a reset
+const maxFrameGap = 1000
+
+// maxReorderDistance is the largest decrease of the frame number that is counted as a reordered or duplicated frame instead of a reset.
+// It is limited by the size of Sequence.received. A decrease to the first frame number since the last reset or before
+// is always counted as a reset, so that a restart shortly after the start of a stream is detected.
+const maxReorderDistance = 63
+
+// Sequence analyses the frame numbers of a camera and counts lost, duplicated and reordered frames.
+// Wrap arounds of the frame number are handled like a regular increase.
+type Sequence struct {
+	NumInOrder    int
+	NumGaps       int
+	NumLost       int
+	NumDuplicates int
+	NumReordered  int
+	NumResets     int
+	started       bool
+	last          uint32
+	// span is the difference between the last and the first frame number since the last reset
+	span uint32
+	// received has bit i set, if frame number last-i was received
+	received uint64
+}
+
+// Add classifies the given frame number and returns the number of frames that were lost since the last frame
+func (s *Sequence) Add(frameId uint32) (order FrameOrder, numLost int) {
+	if !s.started {
+		s.started = true
+		s.reset(frameId)
+		s.NumInOrder++
+		return FrameInOrder, 0
+	}
+
+	// the unsigned difference is correct across a wrap around of the frame number
+	ahead := frameId - s.last
+	behind := s.last - frameId
+	switch {
+	case ahead == 0:
+		s.NumDuplicates++
+		return FrameDuplicate, 0
+	case ahead <= maxFrameGap:
+		if ahead > maxReorderDistance {
+			s.received = 0
+		} else {
+			s.received <<= ahead
+		}
+		s.received |= 1
+		s.last = frameId
+		s.span += ahead
+		if ahead == 1 {
+			s.NumInOrder++
+			return FrameInOrder, 0
+		}
+		numLost = int(ahead - 1)
+		s.NumGaps++
+		s.NumLost += numLost
+		return FrameGap, numLost
+	case behind <= maxReorderDistance && behind < s.span:
+		bit := uint64(1) << behind
+		if s.received&bit != 0 {
+			s.NumDuplicates++
+			return FrameDuplicate, 0
+		}
+		s.received |= bit
+		// the frame was counted as lost by a previous gap
+		s.NumLost--
+		s.NumReordered++
+		return FrameReordered, 0
+	}
+	s.reset(frameId)
+	s.NumResets++
+	return FrameReset, 0
+}
+
+func (s *Sequence) reset(frameId uint32) {
+	s.last = frameId
+	s.span = 0
+	s.received = 1
+}
+
+// LastFrameId returns the highest frame number since the last reset
+func (s *Sequence) LastFrameId() uint32 {
+	return s.last
+}
+
+func (s *Sequence) String() string {
+	return fmt.Sprintf("%v in order | %v gaps (%v lost) | %v duplicates | %v reordered | %v resets",
+		s.NumInOrder, s.NumGaps, s.NumLost, s.NumDuplicates, s.NumReordered, s.NumResets)
+}
@@ -0,0 +1,70 @@
+package timing
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSequence_Add(t *testing.T) {
+	var s Sequence
+	expected := []struct {
+		frameId uint32
+		order   FrameOrder
+		numLost int
+	}{
+		{10, FrameInOrder, 0},
+		{11, FrameInOrder, 0},
+		{11, FrameDuplicate, 0},
+		{15, FrameGap, 3},
+		{13, FrameReordered, 0},
+		{13, FrameDuplicate, 0},
+		{16, FrameInOrder, 0},
+		{3, FrameReset, 0},
+		{4, FrameInOrder, 0},
+		{5000, FrameReset, 0},
+		{5001, FrameInOrder, 0},
+		{2, FrameReset, 0},
+	}
+	for _, e := range expected {
+		order, numLost := s.Add(e.frameId)
+		if order != e.order || numLost != e.numLost {
+			t.Errorf("Frame %v: expected %v with %v lost, got %v with %v lost", e.frameId, e.order, e.numLost, order, numLost)
+		}
+	}
+
+	expectedSnapshot := SequenceSnapshot{NumInOrder: 5, NumGaps: 1, NumLost: 2, NumDuplicates: 2, NumReordered: 1, NumResets: 3}
+	if snapshot := s.Snapshot(); snapshot != expectedSnapshot {
+		t.Errorf("Expected %+v, got %+v", expectedSnapshot, snapshot)
+	}
+}
+
+func TestSequence_WrapAround(t *testing.T) {
+	var s Sequence
+	s.Add(math.MaxUint32 - 1)
+	if order, _ := s.Add(math.MaxUint32); order != FrameInOrder {
+		t.Errorf("Expected %v, got %v", FrameInOrder, order)
+	}
+	if order, _ := s.Add(0); order != FrameInOrder {
+		t.Errorf("Expected %v after wrap around, got %v", FrameInOrder, order)
+	}
+	if order, numLost := s.Add(2); order != FrameGap || numLost != 1 {
+		t.Errorf("Expected %v with 1 lost, got %v with %v lost", FrameGap, order, numLost)
+	}
+	if order, _ := s.Add(math.MaxUint32); order != FrameDuplicate {
+		t.Errorf("Expected %v before wrap around, got %v", FrameDuplicate, order)
+	}
+}
+
+func TestSequence_EarlyReset(t *testing.T) {
+	var s Sequence
+	for frameId := uint32(0); frameId < 20; frameId++ {
+		s.Add(frameId)
+	}
+	// ssl-vision restarts with the same frame numbers as before
+	if order, _ := s.Add(0); order != FrameReset {
+		t.Errorf("Expected %v, got %v", FrameReset, order)
+	}
+	if order, _ := s.Add(1); order != FrameInOrder {
+		t.Errorf("Expected %v after the reset, got %v", FrameInOrder, order)
+	}
+}
@@ -0,0 +1,98 @@
+package timing
+
+import (
+	"math"
+	"time"
+)
+
+// TimingSnapshot is a copy of the current timing statistics, durations are in nanoseconds
+type TimingSnapshot struct {
+	Min    time.Duration `json:"min"`
+	Max    time.Duration `json:"max"`
+	Avg    time.Duration `json:"avg"`
+	Median time.Duration `json:"median"`
+	StdDev time.Duration `json:"stdDev"`
+	// Percentiles are ordered like the configured percentiles
+	Percentiles []PercentileSnapshot `json:"percentiles"`
+	Histogram   Histogram            `json:"histogram"`
+	NumMeasures int                  `json:"numMeasures"`
+	TimeWindow  time.Duration        `json:"timeWindow"`
+}
+
+// PercentileSnapshot is the duration of a percentile, named like p95
+type PercentileSnapshot struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+}
+
+// FrameStatsSnapshot is a copy of the current frame statistics, delta times are in seconds
+type FrameStatsSnapshot struct {
+	Quality        float64 `json:"quality"`
+	Fps            float32 `json:"fps"`
+	DeltaTime      float64 `json:"deltaTime"`
+	DeltaTimeSigma float64 `json:"deltaTimeSigma"`
+	NumFrames      int     `json:"numFrames"`
+}
+
+// SequenceSnapshot is a copy of the frame sequence counters
+type SequenceSnapshot struct {
+	NumInOrder    int `json:"numInOrder"`
+	NumGaps       int `json:"numGaps"`
+	NumLost       int `json:"numLost"`
+	NumDuplicates int `json:"numDuplicates"`
+	NumReordered  int `json:"numReordered"`
+	NumResets     int `json:"numResets"`
+}
+
+func (t *Timing) Snapshot() (s TimingSnapshot) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	s.NumMeasures = t.samples.Len()
+	s.TimeWindow = t.TimeWindow
+	if s.NumMeasures > 0 {
+		s.Min = t.Min
+		s.Max = t.Max
+		s.Avg = t.Avg
+		s.Median = t.Median
+		s.StdDev = t.StdDev
+	}
+	s.Percentiles = make([]PercentileSnapshot, len(t.Distribution.Percentiles))
+	for i, p := range t.Distribution.Percentiles {
+		s.Percentiles[i] = PercentileSnapshot{Name: PercentileName(p), Duration: percentile(t.sorted, p)}
+	}
+	s.Histogram = newHistogram(t.sorted, t.Distribution.HistogramBuckets)
+	return
+}
+
+// Percentile returns the duration of the percentile with the given name, if it is configured
+func (s TimingSnapshot) Percentile(name string) (time.Duration, bool) {
+	for _, p := range s.Percentiles {
+		if p.Name == name {
+			return p.Duration, true
+		}
+	}
+	return 0, false
+}
+
+func (s *FrameStats) Snapshot() (snapshot FrameStatsSnapshot) {
+	snapshot.Quality = s.Quality()
+	snapshot.Fps = s.Fps.Float32()
+	snapshot.DeltaTime, snapshot.DeltaTimeSigma = s.DeltaTime()
+	if math.IsNaN(snapshot.DeltaTime) {
+		// no frame deltas available yet
+		snapshot.DeltaTime = 0
+		snapshot.DeltaTimeSigma = 0
+	}
+	snapshot.NumFrames = s.NumFrames()
+	return
+}
+
+func (s *Sequence) Snapshot() (snapshot SequenceSnapshot) {
+	snapshot.NumInOrder = s.NumInOrder
+	snapshot.NumGaps = s.NumGaps
+	snapshot.NumLost = s.NumLost
+	snapshot.NumDuplicates = s.NumDuplicates
+	snapshot.NumReordered = s.NumReordered
+	snapshot.NumResets = s.NumResets
+	return
+}
@@ -0,0 +1,83 @@
+package timing
+
+import (
+	"iter"
+	"time"
+)
+
+// TimeWindow holds values with the time they were added in chronological order
+// and drops values that are older than the time window when pruned
+type TimeWindow[T any] struct {
+	Duration time.Duration
+	entries  Ring[timedValue[T]]
+}
+
+type timedValue[T any] struct {
+	t time.Time
+	v T
+}
+
+func NewTimeWindow[T any](duration time.Duration) (w *TimeWindow[T]) {
+	w = new(TimeWindow[T])
+	w.Duration = duration
+	return w
+}
+
+// Add adds a value at the given time, which must not be before the time of the previous value
+func (w *TimeWindow[T]) Add(t time.Time, v T) {
+	w.entries.PushBack(timedValue[T]{t: t, v: v})
+}
+
+// Prune drops all values that are older than the time window at the given time
+func (w *TimeWindow[T]) Prune(now time.Time) {
+	w.PruneFunc(now, nil)
+}
+
+// PruneFunc drops all values that are older than the time window at the given time and calls removed for each of them
+func (w *TimeWindow[T]) PruneFunc(now time.Time, removed func(v T)) {
+	tOldest := now.Add(-w.Duration)
+	for w.entries.Len() > 0 && w.entries.Front().t.Before(tOldest) {
+		entry := w.entries.PopFront()
+		if removed != nil {
+			removed(entry.v)
+		}
+	}
+}
+
+func (w *TimeWindow[T]) Clear() {
+	w.entries.Clear()
+}
+
+func (w *TimeWindow[T]) Len() int {
+	return w.entries.Len()
+}
+
+// At returns the i-th oldest value
+func (w *TimeWindow[T]) At(i int) T {
+	return w.entries.At(i).v
+}
+
+// Time returns the time of the i-th oldest value
+func (w *TimeWindow[T]) Time(i int) time.Time {
+	return w.entries.At(i).t
+}
+
+// Span returns the time between the oldest and the newest value
+func (w *TimeWindow[T]) Span() time.Duration {
+	if w.entries.Len() == 0 {
+		return 0
+	}
+	return w.entries.Back().t.Sub(w.entries.Front().t)
+}
+
+// All iterates over the times and values from the oldest to the newest
+func (w *TimeWindow[T]) All() iter.Seq2[time.Time, T] {
+	return func(yield func(time.Time, T) bool) {
+		for i := 0; i < w.entries.Len(); i++ {
+			entry := w.entries.At(i)
+			if !yield(entry.t, entry.v) {
+				return
+			}
+		}
+	}
+}
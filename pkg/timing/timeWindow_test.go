@@ -0,0 +1,37 @@
+package timing
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeWindow_Prune(t *testing.T) {
+	w := NewTimeWindow[int](time.Second)
+	tStart := time.Unix(1000, 0)
+	for i := 0; i < 20; i++ {
+		w.Add(tStart.Add(time.Duration(i)*100*time.Millisecond), i)
+	}
+
+	var removed []int
+	w.PruneFunc(tStart.Add(2*time.Second), func(v int) {
+		removed = append(removed, v)
+	})
+	if len(removed) != 10 || removed[0] != 0 || removed[9] != 9 {
+		t.Errorf("Expected values 0 to 9 to be removed, got %v", removed)
+	}
+	if w.Len() != 10 || w.At(0) != 10 || w.Time(0) != tStart.Add(time.Second) || w.Span() != 900*time.Millisecond {
+		t.Errorf("Unexpected window: %v values from %v, span %v", w.Len(), w.At(0), w.Span())
+	}
+	sum := 0
+	for _, v := range w.All() {
+		sum += v
+	}
+	if sum != 145 {
+		t.Errorf("Expected a sum of 145, got %v", sum)
+	}
+
+	w.Clear()
+	if w.Len() != 0 || w.Span() != 0 {
+		t.Errorf("Expected an empty window, got %v values", w.Len())
+	}
+}
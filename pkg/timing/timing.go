@@ -2,79 +2,153 @@ package timing
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"sync"
 	"time"
 )
 
+// Distribution configures the percentiles and histogram buckets that are calculated for timings
+type Distribution struct {
+	// Percentiles are given within (0, 1], like 0.95
+	Percentiles []float64
+	// HistogramBuckets are the sorted upper bounds of the histogram buckets
+	HistogramBuckets []time.Duration
+}
+
+// DefaultDistribution returns the distribution that is used if none is configured
+func DefaultDistribution() Distribution {
+	return Distribution{
+		Percentiles: []float64{0.9, 0.95, 0.99},
+		HistogramBuckets: []time.Duration{
+			time.Millisecond,
+			2 * time.Millisecond,
+			5 * time.Millisecond,
+			10 * time.Millisecond,
+			15 * time.Millisecond,
+			20 * time.Millisecond,
+			30 * time.Millisecond,
+			50 * time.Millisecond,
+			100 * time.Millisecond,
+			200 * time.Millisecond,
+			500 * time.Millisecond,
+		},
+	}
+}
+
 type Timing struct {
 	TimeWindow time.Duration
 	Min        time.Duration
 	Max        time.Duration
 	Avg        time.Duration
 	Median     time.Duration
-	durations  map[time.Time]time.Duration
+	StdDev     time.Duration
+	// Distribution defines the percentiles and the histogram of the snapshot
+	Distribution Distribution
+	// samples holds all measures of the time window in chronological order
+	samples *TimeWindow[time.Duration]
+	// sorted holds the durations of all samples in ascending order
+	sorted []time.Duration
+	sum    time.Duration
+	sqSum  float64
+	// numRemoved counts the removed samples since sqSum was last recalculated
+	numRemoved int
+	clock      Clock
 	mutex      sync.Mutex
 }
 
-func NewTiming(timeWindow time.Duration) (t *Timing) {
+func NewTiming(timeWindow time.Duration, clock Clock) (t *Timing) {
 	t = new(Timing)
 	t.TimeWindow = timeWindow
-	t.durations = map[time.Time]time.Duration{}
+	t.clock = clock
+	t.samples = NewTimeWindow[time.Duration](timeWindow)
+	t.Distribution = DefaultDistribution()
 	return t
 }
 
 func (t *Timing) Clear() {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
-	t.durations = map[time.Time]time.Duration{}
+	t.samples.Clear()
+	t.sorted = t.sorted[:0]
+	t.sum = 0
+	t.sqSum = 0
+	t.numRemoved = 0
 }
 
 func (t *Timing) Add(duration time.Duration) {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
-	now := time.Now()
-	t.durations[now] = duration
+	now := t.clock.Now()
+	t.samples.Add(now, duration)
+	t.insertSorted(duration)
+	t.sum += duration
+	t.sqSum += float64(duration) * float64(duration)
 
-	lastValidMeasureTime := now.Add(-t.TimeWindow)
-	var toBeDeleted []time.Time
-	for measuredTime := range t.durations {
-		if measuredTime.Before(lastValidMeasureTime) {
-			toBeDeleted = append(toBeDeleted, measuredTime)
+	t.samples.PruneFunc(now, func(d time.Duration) {
+		t.removeSorted(d)
+		t.sum -= d
+		t.sqSum -= float64(d) * float64(d)
+		t.numRemoved++
+	})
+	n := len(t.sorted)
+	if t.numRemoved >= n {
+		// the incremental sum of squares accumulates rounding errors, so recalculate it once per window
+		t.sqSum = 0
+		for _, d := range t.sorted {
+			t.sqSum += float64(d) * float64(d)
 		}
-	}
-	for _, measuredTime := range toBeDeleted {
-		delete(t.durations, measuredTime)
+		t.numRemoved = 0
 	}
 
-	sortedDurations := t.sortedDurations()
-	t.Min = sortedDurations[0]
-	t.Max = sortedDurations[len(sortedDurations)-1]
-	t.Avg = t.calcAvg()
-	t.Median = sortedDurations[len(sortedDurations)/2]
+	t.Min = t.sorted[0]
+	t.Max = t.sorted[n-1]
+	t.Avg = t.sum / time.Duration(n)
+	t.Median = t.sorted[n/2]
+	variance := t.sqSum/float64(n) - float64(t.Avg)*float64(t.Avg)
+	// rounding errors can still lead to slightly negative values
+	t.StdDev = time.Duration(math.Sqrt(math.Max(0, variance)))
 }
 
-func (t *Timing) calcAvg() time.Duration {
-	sum := time.Duration(0)
-	for _, d := range t.durations {
-		sum += d
-	}
-	return time.Duration(sum.Nanoseconds() / int64(len(t.durations)))
+// NumMeasures returns the number of measures within the time window
+func (t *Timing) NumMeasures() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.samples.Len()
 }
 
-func (t *Timing) sortedDurations() []time.Duration {
-	durations := make([]time.Duration, len(t.durations))
-	i := 0
-	for _, d := range t.durations {
-		durations[i] = d
-		i++
-	}
-	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
-	return durations
+func (t *Timing) insertSorted(d time.Duration) {
+	i := sort.Search(len(t.sorted), func(i int) bool { return t.sorted[i] >= d })
+	t.sorted = append(t.sorted, 0)
+	copy(t.sorted[i+1:], t.sorted[i:])
+	t.sorted[i] = d
+}
+
+func (t *Timing) removeSorted(d time.Duration) {
+	i := sort.Search(len(t.sorted), func(i int) bool { return t.sorted[i] >= d })
+	t.sorted = append(t.sorted[:i], t.sorted[i+1:]...)
+}
+
+// Percentile returns the duration below which the given share of all measures in the time window lies
+func (t *Timing) Percentile(p float64) time.Duration {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return percentile(t.sorted, p)
+}
+
+// Histogram returns the distribution of all measures in the time window over the configured histogram buckets
+func (t *Timing) Histogram() Histogram {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return newHistogram(t.sorted, t.Distribution.HistogramBuckets)
 }
 
 func (t *Timing) String() string {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
-	return fmt.Sprintf("Min: %10v Max: %10v Avg: %10v Median: %10v (%v measures in %v)", t.Min, t.Max, t.Avg, t.Median, len(t.durations), t.TimeWindow)
+	str := fmt.Sprintf("Min: %10v Max: %10v Avg: %10v Median: %10v StdDev: %10v", t.Min, t.Max, t.Avg, t.Median, t.StdDev)
+	for _, p := range t.Distribution.Percentiles {
+		str += fmt.Sprintf(" %v: %10v", PercentileName(p), percentile(t.sorted, p))
+	}
+	return str + fmt.Sprintf(" (%v measures in %v)", t.samples.Len(), t.TimeWindow)
 }
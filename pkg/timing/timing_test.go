@@ -0,0 +1,115 @@
+package timing
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTiming_Add(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	timing := NewTiming(time.Second, clock)
+
+	timing.Add(time.Millisecond * 10)
+	clock.Add(time.Millisecond * 500)
+	timing.Add(time.Millisecond * 30)
+	clock.Add(time.Millisecond * 400)
+	timing.Add(time.Millisecond * 20)
+
+	if timing.Min != time.Millisecond*10 || timing.Max != time.Millisecond*30 {
+		t.Errorf("Min/Max %v/%v != 10ms/30ms", timing.Min, timing.Max)
+	}
+	if timing.Avg != time.Millisecond*20 || timing.Median != time.Millisecond*20 {
+		t.Errorf("Avg/Median %v/%v != 20ms/20ms", timing.Avg, timing.Median)
+	}
+
+	clock.Add(time.Millisecond * 200)
+	timing.Add(time.Millisecond * 40)
+
+	if timing.Min != time.Millisecond*20 {
+		t.Errorf("Min %v != 20ms after the oldest sample left the time window", timing.Min)
+	}
+}
+
+func TestUnixTime(t *testing.T) {
+	tUnix := UnixTime(1000.25)
+	if tUnix.Unix() != 1000 || tUnix.Nanosecond() != 250000000 {
+		t.Errorf("Unexpected time %v", tUnix)
+	}
+}
+
+func TestTiming_Percentile(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	timing := NewTiming(time.Second, clock)
+	timing.Distribution.Percentiles = []float64{0.5, 0.95}
+	for i := 1; i <= 100; i++ {
+		clock.Add(time.Millisecond)
+		timing.Add(time.Millisecond * time.Duration(i))
+	}
+	if p := timing.Percentile(0.95); p != time.Millisecond*95 {
+		t.Errorf("p95 %v != 95ms", p)
+	}
+	if p := timing.Percentile(1); p != time.Millisecond*100 {
+		t.Errorf("p100 %v != 100ms", p)
+	}
+	if name := PercentileName(0.95); name != "p95" {
+		t.Errorf("Name %v != p95", name)
+	}
+
+	snapshot := timing.Snapshot()
+	if len(snapshot.Percentiles) != 2 || snapshot.Percentiles[0].Name != "p50" {
+		t.Errorf("Unexpected percentiles %v", snapshot.Percentiles)
+	}
+	if p, ok := snapshot.Percentile("p95"); !ok || p != time.Millisecond*95 {
+		t.Errorf("p95 %v != 95ms", p)
+	}
+	if _, ok := snapshot.Percentile("p99"); ok {
+		t.Error("Unexpected p99, which is not configured")
+	}
+}
+
+func TestTiming_StdDevAndHistogram(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	timing := NewTiming(time.Second, clock)
+	for _, d := range []time.Duration{2, 4, 4, 4, 5, 5, 7, 9} {
+		clock.Add(time.Millisecond)
+		timing.Add(d * time.Millisecond)
+	}
+	if timing.StdDev != 2*time.Millisecond {
+		t.Errorf("StdDev %v != 2ms", timing.StdDev)
+	}
+
+	histogram := timing.Histogram()
+	// buckets: 1ms 2ms 5ms 10ms ...
+	expected := map[int]int{1: 1, 2: 5, 3: 2}
+	for i, count := range histogram.Counts {
+		if count != expected[i] {
+			t.Errorf("Bucket %v has %v measures instead of %v", i, count, expected[i])
+		}
+	}
+}
+
+func TestParsePercentiles(t *testing.T) {
+	if p, err := ParsePercentiles("0.5, 0.99"); err != nil || len(p) != 2 || p[1] != 0.99 {
+		t.Errorf("Unexpected result: %v %v", p, err)
+	}
+	if _, err := ParsePercentiles("95"); err == nil {
+		t.Error("Expected an error for a percentile above 1")
+	}
+}
+
+func TestTiming_EqualTimestamps(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	timing := NewTiming(time.Second, clock)
+	timing.Add(time.Millisecond * 10)
+	timing.Add(time.Millisecond * 20)
+	timing.Add(time.Millisecond * 30)
+	if n := timing.Snapshot().NumMeasures; n != 3 {
+		t.Errorf("%v measures with equal timestamps instead of 3", n)
+	}
+
+	clock.Add(time.Millisecond * 1500)
+	timing.Add(time.Millisecond * 40)
+	if timing.Min != time.Millisecond*40 || timing.Avg != time.Millisecond*40 || timing.StdDev != 0 {
+		t.Errorf("Old measures were not removed: min %v avg %v stdDev %v", timing.Min, timing.Avg, timing.StdDev)
+	}
+}
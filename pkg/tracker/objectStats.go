@@ -0,0 +1,66 @@
+package tracker
+
+import (
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"math"
+	"time"
+)
+
+// ObjectStats tracks the visibility and velocity noise of a tracked object
+type ObjectStats struct {
+	Visibility   float32
+	LastSeen     time.Time
+	lastVelocity *[3]float64
+	// velocityChanges holds the absolute velocity change between two frames within the time window
+	velocityChanges *timing.TimeWindow[float64]
+}
+
+func NewObjectStats(timeWindow time.Duration) (s *ObjectStats) {
+	s = new(ObjectStats)
+	s.velocityChanges = timing.NewTimeWindow[float64](timeWindow)
+	return s
+}
+
+// Add adds a new sample with the velocity of the object, if available
+func (s *ObjectStats) Add(t time.Time, visibility *float32, velocity *[3]float64) {
+	if visibility != nil {
+		s.Visibility = *visibility
+	} else {
+		s.Visibility = 1
+	}
+	s.LastSeen = t
+	if velocity != nil && s.lastVelocity != nil {
+		dx := velocity[0] - s.lastVelocity[0]
+		dy := velocity[1] - s.lastVelocity[1]
+		dz := velocity[2] - s.lastVelocity[2]
+		s.velocityChanges.Add(t, math.Sqrt(dx*dx+dy*dy+dz*dz))
+	}
+	s.lastVelocity = velocity
+	s.velocityChanges.Prune(t)
+}
+
+// VelocityNoise returns the root mean square of the velocity change between two frames in m/s
+func (s *ObjectStats) VelocityNoise() float64 {
+	if s.velocityChanges.Len() == 0 {
+		return 0
+	}
+	var sqSum float64
+	for _, dv := range s.velocityChanges.All() {
+		sqSum += dv * dv
+	}
+	return math.Sqrt(sqSum / float64(s.velocityChanges.Len()))
+}
+
+func vector2(v *Vector2) *[3]float64 {
+	if v == nil {
+		return nil
+	}
+	return &[3]float64{float64(v.GetX()), float64(v.GetY()), 0}
+}
+
+func vector3(v *Vector3) *[3]float64 {
+	if v == nil {
+		return nil
+	}
+	return &[3]float64{float64(v.GetX()), float64(v.GetY()), float64(v.GetZ())}
+}
@@ -0,0 +1,64 @@
+package tracker
+
+import "github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+
+// SourceSnapshot is a copy of the current statistics of a tracker source, velocities are in m/s
+type SourceSnapshot struct {
+	Uuid               string                    `json:"uuid"`
+	SourceName         string                    `json:"sourceName"`
+	Capabilities       []string                  `json:"capabilities"`
+	Frames             timing.FrameStatsSnapshot `json:"frames"`
+	TimingReceiving    timing.TimingSnapshot     `json:"timingReceiving"`
+	NumBalls           int                       `json:"numBalls"`
+	NumBlue            int                       `json:"numBlue"`
+	NumYellow          int                       `json:"numYellow"`
+	BallVisibility     float32                   `json:"ballVisibility"`
+	BallVelocityNoise  float64                   `json:"ballVelocityNoise"`
+	RobotVelocityNoise float64                   `json:"robotVelocityNoise"`
+	Robots             []RobotSnapshot           `json:"robots"`
+}
+
+type RobotSnapshot struct {
+	Id            uint32  `json:"id"`
+	Team          string  `json:"team"`
+	Visibility    float32 `json:"visibility"`
+	VelocityNoise float64 `json:"velocityNoise"`
+}
+
+func (s *Stats) Snapshot() []SourceSnapshot {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	snapshots := []SourceSnapshot{}
+	for _, uuid := range s.SortedSources() {
+		snapshots = append(snapshots, s.Sources[uuid].Snapshot())
+	}
+	return snapshots
+}
+
+func (s *SourceStats) Snapshot() (snapshot SourceSnapshot) {
+	snapshot.Uuid = s.Uuid
+	snapshot.SourceName = s.SourceName
+	snapshot.Capabilities = []string{}
+	for _, capability := range s.Capabilities {
+		snapshot.Capabilities = append(snapshot.Capabilities, capability.String())
+	}
+	snapshot.Frames = s.FrameStats.Snapshot()
+	snapshot.TimingReceiving = s.TimingReceiving.Snapshot()
+	snapshot.NumBalls = s.NumBalls
+	snapshot.NumBlue = s.NumRobots[Team_BLUE]
+	snapshot.NumYellow = s.NumRobots[Team_YELLOW]
+	snapshot.BallVisibility = s.Ball.Visibility
+	snapshot.BallVelocityNoise = s.Ball.VelocityNoise()
+	snapshot.RobotVelocityNoise = s.MeanRobotVelocityNoise()
+	snapshot.Robots = []RobotSnapshot{}
+	for _, key := range s.sortedRobots() {
+		robot := s.Robots[key]
+		snapshot.Robots = append(snapshot.Robots, RobotSnapshot{
+			Id:            key.Id,
+			Team:          key.Team.String(),
+			Visibility:    robot.Visibility,
+			VelocityNoise: robot.VelocityNoise(),
+		})
+	}
+	return
+}
@@ -0,0 +1,160 @@
+package tracker
+
+import (
+	"fmt"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Stats collects statistics of all tracker sources
+type Stats struct {
+	Sources      map[string]*SourceStats
+	timeWindow   time.Duration
+	distribution timing.Distribution
+	clock        timing.Clock
+	Mutex        sync.Mutex
+}
+
+// SourceStats collects statistics of a single tracker source, identified by its UUID
+type SourceStats struct {
+	Uuid            string
+	SourceName      string
+	Capabilities    []Capability
+	FrameStats      *timing.FrameStats
+	TimingReceiving *timing.Timing
+	NumBalls        int
+	NumRobots       map[Team]int
+	Ball            *ObjectStats
+	Robots          map[RobotKey]*ObjectStats
+	timeWindow      time.Duration
+}
+
+// RobotKey identifies a robot
+type RobotKey struct {
+	Id   uint32
+	Team Team
+}
+
+func NewStats(timeWindow time.Duration, distribution timing.Distribution, clock timing.Clock) (s *Stats) {
+	s = new(Stats)
+	s.Sources = map[string]*SourceStats{}
+	s.timeWindow = timeWindow
+	s.distribution = distribution
+	s.clock = clock
+	return s
+}
+
+func NewSourceStats(uuid string, timeWindow time.Duration, distribution timing.Distribution, clock timing.Clock) (s *SourceStats) {
+	s = new(SourceStats)
+	s.Uuid = uuid
+	s.FrameStats = timing.NewFrameStats(timeWindow, clock)
+	s.TimingReceiving = timing.NewTiming(timeWindow, clock)
+	s.TimingReceiving.Distribution = distribution
+	s.NumRobots = map[Team]int{}
+	s.Ball = NewObjectStats(timeWindow)
+	s.Robots = map[RobotKey]*ObjectStats{}
+	s.timeWindow = timeWindow
+	return s
+}
+
+func (s *Stats) Process(wrapper *TrackerWrapperPacket) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	uuid := wrapper.GetUuid()
+	sourceStats, ok := s.Sources[uuid]
+	if !ok {
+		sourceStats = NewSourceStats(uuid, s.timeWindow, s.distribution, s.clock)
+		s.Sources[uuid] = sourceStats
+	}
+	sourceStats.SourceName = wrapper.GetSourceName()
+	if wrapper.TrackedFrame != nil {
+		sourceStats.process(wrapper.TrackedFrame, s.clock.Now())
+	}
+}
+
+func (s *SourceStats) process(frame *TrackedFrame, tReceived time.Time) {
+	tFrame := timing.UnixTime(frame.GetTimestamp())
+	s.TimingReceiving.Add(tReceived.Sub(tFrame))
+	s.FrameStats.Add(frame.GetFrameNumber(), tFrame)
+	s.FrameStats.Prune(tFrame.Add(-s.timeWindow))
+	s.Capabilities = frame.Capabilities
+
+	s.NumBalls = len(frame.Balls)
+	if len(frame.Balls) > 0 {
+		// only the primary ball is tracked
+		ball := frame.Balls[0]
+		s.Ball.Add(tFrame, ball.Visibility, vector3(ball.Vel))
+	}
+
+	s.NumRobots = map[Team]int{}
+	for _, robot := range frame.Robots {
+		key := RobotKey{Id: robot.GetRobotId().GetId(), Team: robot.GetRobotId().GetTeam()}
+		s.NumRobots[key.Team]++
+		robotStats, ok := s.Robots[key]
+		if !ok {
+			robotStats = NewObjectStats(s.timeWindow)
+			s.Robots[key] = robotStats
+		}
+		robotStats.Add(tFrame, robot.Visibility, vector2(robot.Vel))
+	}
+	for key, robot := range s.Robots {
+		if tFrame.Sub(robot.LastSeen) > s.timeWindow {
+			delete(s.Robots, key)
+		}
+	}
+}
+
+func (s *Stats) SortedSources() []string {
+	sources := make([]string, 0, len(s.Sources))
+	for uuid := range s.Sources {
+		sources = append(sources, uuid)
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+func (s *SourceStats) sortedRobots() []RobotKey {
+	keys := make([]RobotKey, 0, len(s.Robots))
+	for key := range s.Robots {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Team != keys[j].Team {
+			return keys[i].Team < keys[j].Team
+		}
+		return keys[i].Id < keys[j].Id
+	})
+	return keys
+}
+
+// MeanRobotVelocityNoise returns the mean velocity noise of all robots in m/s
+func (s *SourceStats) MeanRobotVelocityNoise() float64 {
+	if len(s.Robots) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, robot := range s.Robots {
+		sum += robot.VelocityNoise()
+	}
+	return sum / float64(len(s.Robots))
+}
+
+func (s *SourceStats) String() string {
+	str := fmt.Sprintf("%v (%v) %v\n", s.SourceName, s.Uuid, s.FrameStats)
+	str += fmt.Sprintf("Receiving Time: %v\n", s.TimingReceiving)
+	str += fmt.Sprintf("%v blue | %v yellow | %v balls | ball visibility %3.0f%% | velocity noise ball %.3fm/s robots %.3fm/s\n",
+		s.NumRobots[Team_BLUE], s.NumRobots[Team_YELLOW], s.NumBalls,
+		s.Ball.Visibility*100, s.Ball.VelocityNoise(), s.MeanRobotVelocityNoise())
+	return str
+}
+
+func (s *Stats) String() string {
+	str := ""
+	for _, uuid := range s.SortedSources() {
+		str += s.Sources[uuid].String()
+	}
+	return str
+}
@@ -0,0 +1,54 @@
+package tracker
+
+import (
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"google.golang.org/protobuf/proto"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestStats_Process(t *testing.T) {
+	tStart := time.Unix(1000, 0)
+	clock := timing.NewManualClock(tStart)
+	stats := NewStats(time.Second, timing.DefaultDistribution(), clock)
+
+	for i := 0; i < 10; i++ {
+		tFrame := tStart.Add(time.Duration(i) * 10 * time.Millisecond)
+		clock.Set(tFrame.Add(5 * time.Millisecond))
+		// alternate the robot velocity between 1 and 1.2 m/s
+		velocity := float32(1)
+		if i%2 == 1 {
+			velocity = 1.2
+		}
+		stats.Process(&TrackerWrapperPacket{
+			Uuid:       proto.String("uuid"),
+			SourceName: proto.String("test"),
+			TrackedFrame: &TrackedFrame{
+				FrameNumber: proto.Uint32(uint32(i)),
+				Timestamp:   proto.Float64(float64(tFrame.UnixNano()) / 1e9),
+				Robots: []*TrackedRobot{{
+					RobotId:    &RobotId{Id: proto.Uint32(3), Team: Team_BLUE.Enum()},
+					Pos:        &Vector2{X: proto.Float32(0), Y: proto.Float32(0)},
+					Vel:        &Vector2{X: proto.Float32(velocity), Y: proto.Float32(0)},
+					Visibility: proto.Float32(0.8),
+				}},
+			},
+		})
+	}
+
+	snapshots := stats.Snapshot()
+	if len(snapshots) != 1 {
+		t.Fatalf("Expected 1 source, got %v", len(snapshots))
+	}
+	source := snapshots[0]
+	if source.NumBlue != 1 || source.NumYellow != 0 {
+		t.Errorf("Unexpected robot count: %v blue, %v yellow", source.NumBlue, source.NumYellow)
+	}
+	if math.Abs(source.RobotVelocityNoise-0.2) > 1e-3 {
+		t.Errorf("Expected velocity noise of 0.2, got %v", source.RobotVelocityNoise)
+	}
+	if source.TimingReceiving.Max < 4*time.Millisecond || source.TimingReceiving.Max > 6*time.Millisecond {
+		t.Errorf("Expected latency of 5ms, got %v", source.TimingReceiving.Max)
+	}
+}
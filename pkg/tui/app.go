@@ -0,0 +1,519 @@
+package tui
+
+import (
+	"fmt"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/inspector"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/vision"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const helpText = "[yellow]Tab[-] switch pane  [yellow]Enter[-] drill down  [yellow]Esc[-] back  " +
+	"[yellow]s[-] sort column  [yellow]r[-] reverse  [yellow]/[-] filter  [yellow]c[-] camera coverage  [yellow]C[-] field coverage  [yellow]n[-] noise mode  [yellow]q[-] quit"
+
+// App is an interactive terminal UI that shows the statistics of an inspector
+type App struct {
+	inspector *inspector.Inspector
+	app       *tview.Application
+	pages     *tview.Pages
+	overview  *tview.TextView
+	cameras   *sortableTable
+	camera    *tview.TextView
+	robots    *sortableTable
+	robot     *tview.TextView
+	coverage  *tview.TextView
+	log       *tview.TextView
+	filter    *tview.InputField
+	// filterTarget is the table that the filter input applies to
+	filterTarget *sortableTable
+	focusOrder   []tview.Primitive
+	// coverageCamId is the camera of the shown coverage, -1 for all cameras
+	coverageCamId int
+	logIndex      int
+	appLog        *logWriter
+	snapshot      inspector.Snapshot
+	// thresholds define the colors of quality values
+	thresholds timing.QualityThresholds
+}
+
+// logWriter buffers application log output until the next refresh
+type logWriter struct {
+	lines []string
+	mutex sync.Mutex
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.lines = append(w.lines, strings.TrimRight(string(p), "\n"))
+	return len(p), nil
+}
+
+func (w *logWriter) take() []string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	lines := w.lines
+	w.lines = nil
+	return lines
+}
+
+func NewApp(inspector *inspector.Inspector) (a *App) {
+	a = new(App)
+	a.inspector = inspector
+	a.thresholds = inspector.Stats.QualityThresholds
+	a.app = tview.NewApplication()
+
+	a.overview = tview.NewTextView().SetDynamicColors(true)
+	a.overview.SetBorder(true).SetTitle("Sources")
+
+	a.cameras = newSortableTable("Cameras", "Camera", "Source", "FPS", "Quality", "Processing", "Receiving", "Blue", "Yellow", "Balls", "Reprojection")
+	a.cameras.SetSelectedFunc(func(int, int) {
+		a.app.SetFocus(a.robots)
+	})
+	a.cameras.SetSelectionChangedFunc(func(int, int) {
+		a.updateCamera()
+	})
+
+	a.camera = tview.NewTextView().SetDynamicColors(true)
+	a.camera.SetBorder(true).SetTitle("Camera")
+
+	a.robots = newSortableTable("Objects", "Object", "Quality", "FPS", "Age", "X", "Y")
+	a.robots.SetSelectedFunc(func(int, int) {
+		a.app.SetFocus(a.robot)
+	})
+	a.robots.SetSelectionChangedFunc(func(int, int) {
+		a.updateRobot()
+	})
+
+	a.robot = tview.NewTextView().SetDynamicColors(true)
+	a.robot.SetBorder(true).SetTitle("Object")
+
+	a.log = tview.NewTextView().SetScrollable(true).SetMaxLines(1000)
+	a.log.SetBorder(true).SetTitle("Log")
+	a.appLog = new(logWriter)
+
+	a.coverage = tview.NewTextView().SetDynamicColors(true)
+	a.coverage.SetBorder(true).SetTitle("Coverage")
+
+	a.filter = tview.NewInputField().SetLabel("Filter: ")
+	a.filter.SetChangedFunc(func(text string) {
+		if a.filterTarget != nil {
+			a.filterTarget.filter = text
+			a.refreshTables()
+		}
+	})
+	a.filter.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			a.filter.SetText("")
+		}
+		a.pages.HidePage("filter")
+		if a.filterTarget != nil {
+			a.app.SetFocus(a.filterTarget)
+		}
+	})
+
+	details := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(a.camera, 10, 0, false).
+		AddItem(a.robots, 0, 2, false).
+		AddItem(a.robot, 6, 0, false)
+	main := tview.NewFlex().
+		AddItem(a.cameras, 0, 3, true).
+		AddItem(details, 0, 2, false)
+	help := tview.NewTextView().SetDynamicColors(true).SetText(helpText)
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(a.overview, 0, 1, false).
+		AddItem(main, 0, 3, true).
+		AddItem(a.log, 10, 0, false).
+		AddItem(help, 1, 0, false)
+	filterBar := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(a.filter, 1, 0, true)
+
+	a.pages = tview.NewPages().
+		AddPage("main", layout, true, true).
+		AddPage("coverage", a.coverage, true, false).
+		AddPage("filter", filterBar, true, false)
+
+	a.focusOrder = []tview.Primitive{a.cameras, a.robots, a.robot, a.log}
+	a.app.SetRoot(a.pages, true).SetFocus(a.cameras)
+	a.app.SetInputCapture(a.handleKey)
+	return a
+}
+
+// LogWriter returns a writer that appends to the log pane, for redirecting application log output
+func (a *App) LogWriter() io.Writer {
+	return a.appLog
+}
+
+// Run refreshes the UI with the given interval and blocks until the user quits
+func (a *App) Run(interval time.Duration) error {
+	go func() {
+		for {
+			snapshot := a.inspector.Snapshot()
+			entries, next := a.inspector.Stats.LogEntriesSince(a.logIndex)
+			a.logIndex = next
+			entries = append(entries, a.appLog.take()...)
+			a.app.QueueUpdateDraw(func() {
+				a.snapshot = snapshot
+				a.refresh()
+				for _, entry := range entries {
+					_, _ = fmt.Fprintln(a.log, entry)
+				}
+			})
+			time.Sleep(interval)
+		}
+	}()
+	return a.app.Run()
+}
+
+func (a *App) handleKey(event *tcell.EventKey) *tcell.EventKey {
+	if name, _ := a.pages.GetFrontPage(); name == "filter" {
+		return event
+	}
+	switch event.Key() {
+	case tcell.KeyTab:
+		a.cycleFocus(1)
+		return nil
+	case tcell.KeyBacktab:
+		a.cycleFocus(-1)
+		return nil
+	case tcell.KeyEscape:
+		if name, _ := a.pages.GetFrontPage(); name == "coverage" {
+			a.pages.SwitchToPage("main")
+		} else if a.app.GetFocus() == a.robot {
+			a.app.SetFocus(a.robots)
+		} else {
+			a.app.SetFocus(a.cameras)
+		}
+		return nil
+	case tcell.KeyRune:
+		switch event.Rune() {
+		case 'q':
+			a.app.Stop()
+			return nil
+		case 's':
+			if table := a.focusedTable(); table != nil {
+				table.nextSortColumn()
+				a.refreshTables()
+			}
+			return nil
+		case 'r':
+			if table := a.focusedTable(); table != nil {
+				table.reverse()
+				a.refreshTables()
+			}
+			return nil
+		case '/':
+			if table := a.focusedTable(); table != nil {
+				a.filterTarget = table
+				a.filter.SetText(table.filter)
+				a.pages.ShowPage("filter")
+				a.app.SetFocus(a.filter)
+			}
+			return nil
+		case 'n':
+			a.inspector.Stats.SetAssumeStationary(!a.snapshot.Vision.AssumeStationary)
+			return nil
+		case 'c', 'C':
+			if name, _ := a.pages.GetFrontPage(); name == "coverage" {
+				a.pages.SwitchToPage("main")
+			} else {
+				a.coverageCamId = -1
+				if cam, ok := a.selectedCamera(); ok && event.Rune() == 'c' {
+					a.coverageCamId = cam.CameraId
+				}
+				a.updateCoverage()
+				a.pages.SwitchToPage("coverage")
+			}
+			return nil
+		}
+	}
+	return event
+}
+
+func (a *App) focusedTable() *sortableTable {
+	switch a.app.GetFocus() {
+	case a.cameras.Table:
+		return a.cameras
+	case a.robots.Table:
+		return a.robots
+	}
+	return nil
+}
+
+func (a *App) cycleFocus(step int) {
+	focused := a.app.GetFocus()
+	for i, p := range a.focusOrder {
+		if p == focused || (p == a.cameras && focused == a.cameras.Table) || (p == a.robots && focused == a.robots.Table) {
+			next := (i + step + len(a.focusOrder)) % len(a.focusOrder)
+			a.app.SetFocus(a.focusOrder[next])
+			return
+		}
+	}
+	a.app.SetFocus(a.cameras)
+}
+
+func (a *App) refresh() {
+	a.updateOverview()
+	a.refreshTables()
+	if name, _ := a.pages.GetFrontPage(); name == "coverage" {
+		a.updateCoverage()
+	}
+}
+
+func (a *App) refreshTables() {
+	a.updateCameras()
+	a.updateCamera()
+}
+
+func (a *App) updateOverview() {
+	s := a.snapshot
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "Vision: %v\n", strings.Join(s.Sources, " "))
+	var camSources []string
+	camerasBySource := map[string][]string{}
+	for _, cam := range s.Vision.Cameras {
+		if _, ok := camerasBySource[cam.Source]; !ok {
+			camSources = append(camSources, cam.Source)
+		}
+		camerasBySource[cam.Source] = append(camerasBySource[cam.Source], strconv.Itoa(cam.CameraId))
+	}
+	sort.Strings(camSources)
+	for _, source := range camSources {
+		_, _ = fmt.Fprintf(&b, "Vision %v: cameras %v\n", tview.Escape(source), strings.Join(camerasBySource[source], " "))
+	}
+	referee := s.Referee
+	gameState := string(referee.GameState)
+	if gameState == "" {
+		gameState = "unknown"
+	}
+	_, _ = fmt.Fprintf(&b, "Game controller: %v (%v, %v) from %v\n",
+		gameState, referee.Stage, referee.Command, strings.Join(referee.SourceIps, " "))
+	for _, clock := range s.Clocks {
+		_, _ = fmt.Fprintf(&b, "Clock %v: offset %v | RTT %v\n",
+			clock.Host, formatDuration(clock.ClockOffset.Median), formatDuration(clock.RTT.Median))
+	}
+	for _, source := range s.Tracker {
+		_, _ = fmt.Fprintf(&b, "Tracker %v: %.1f fps | latency %v\n",
+			tview.Escape(source.SourceName), source.Frames.Fps, formatDuration(source.TimingReceiving.Median))
+	}
+	for _, stream := range s.Network {
+		for _, source := range stream.Sources {
+			color := ""
+			if source.NumUnmarshalFailures > 0 || source.NumTruncated > 0 {
+				color = "[yellow]"
+			}
+			_, _ = fmt.Fprintf(&b, "%vNetwork %v %v: %.1f packets/s | %.1f kB/s | %v B | jitter %v | max gap %v | %v failed | %v truncated[-]\n",
+				color, stream.Name, source.Source, source.PacketRate, source.BytesPerSecond/1000, source.Size.Avg,
+				formatDuration(source.InterArrival.StdDev), formatDuration(source.InterArrival.Max),
+				source.NumUnmarshalFailures, source.NumTruncated)
+		}
+	}
+	for _, camSources := range s.Vision.CamSources {
+		if camSources.Conflict {
+			_, _ = fmt.Fprintf(&b, "[red]Camera %v is published by multiple hosts: %v (%v switches)[-]\n",
+				camSources.CameraId, strings.Join(camSources.Active, " "), camSources.NumSwitches)
+		}
+	}
+	for _, duplicate := range s.Vision.Duplicates {
+		if duplicate.Active {
+			_, _ = fmt.Fprintf(&b, "[red]Duplicate %v[-]\n", duplicate)
+		}
+	}
+	for _, alert := range s.Alerts {
+		_, _ = fmt.Fprintf(&b, "[red]Alert: %v[-]\n", tview.Escape(alert.Message))
+	}
+	a.overview.SetText(b.String())
+}
+
+func (a *App) updateCameras() {
+	var rows [][]cell
+	for _, cam := range a.snapshot.Vision.Cameras {
+		source := cell{text: cam.Source}
+		if !cam.Primary {
+			source.color = tcell.ColorRed
+		}
+		rows = append(rows, []cell{
+			{text: cameraKey(cam), value: float64(cam.CameraId)},
+			source,
+			floatCell("%.1f", float64(cam.Frames.Fps)),
+			qualityCell(cam.Frames.Quality, a.thresholds),
+			durationCell(cam.TimingProcessing.Median),
+			durationCell(cam.TimingReceiving.Median),
+			intCell(cam.NumVisibleBlue),
+			intCell(cam.NumVisibleYellow),
+			intCell(len(cam.Balls)),
+			floatCell("%.2fpx", cam.Reprojection.Error.Mean),
+		})
+	}
+	a.cameras.setRows(rows)
+}
+
+// cameraKey returns the text of the first cell of a camera, which identifies the camera in the table
+func cameraKey(cam vision.CamSnapshot) string {
+	if cam.Primary {
+		return strconv.Itoa(cam.CameraId)
+	}
+	return fmt.Sprintf("%v (%v)", cam.CameraId, cam.Source)
+}
+
+// selectedCamera returns the snapshot of the selected camera
+func (a *App) selectedCamera() (vision.CamSnapshot, bool) {
+	key := a.cameras.SelectedKey()
+	for _, cam := range a.snapshot.Vision.Cameras {
+		if cameraKey(cam) == key {
+			return cam, true
+		}
+	}
+	return vision.CamSnapshot{}, false
+}
+
+func (a *App) updateCamera() {
+	cam, ok := a.selectedCamera()
+	if !ok {
+		a.camera.SetText("No camera selected")
+		a.robots.setRows(nil)
+		a.updateRobot()
+		return
+	}
+	a.camera.SetTitle(fmt.Sprintf("Camera %v from %v", cam.CameraId, tview.Escape(cam.Source)))
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "Frames: %.1f fps | %v%3.0f%%[-] quality | dt %.1fms ± %.1fms\n",
+		cam.Frames.Fps, colorTag(cam.Frames.Quality, a.thresholds), cam.Frames.Quality*100,
+		cam.Frames.DeltaTime*1000, cam.Frames.DeltaTimeSigma*1000)
+	_, _ = fmt.Fprintf(&b, "Sequence: %v gaps (%v lost) | %v duplicates | %v reordered | %v resets\n",
+		cam.Sequence.NumGaps, cam.Sequence.NumLost, cam.Sequence.NumDuplicates, cam.Sequence.NumReordered, cam.Sequence.NumResets)
+	_, _ = fmt.Fprintf(&b, "Capture: interval %v ± %v | %v non-monotonic | %v missing | drift %.1fppm (%+.1fppm, %v to other cameras)\n",
+		formatDuration(cam.Capture.Interval.Median), formatDuration(cam.Capture.Interval.StdDev),
+		cam.Capture.NumNonMonotonic, cam.Capture.NumMissingCaptures,
+		cam.Capture.Drift, cam.Capture.RelativeDrift, formatDuration(cam.Capture.RelativeOffset))
+	_, _ = fmt.Fprintf(&b, "Processing: %v\n", formatTiming(cam.TimingProcessing))
+	_, _ = fmt.Fprintf(&b, "Receiving:  %v\n", formatTiming(cam.TimingReceiving))
+	_, _ = fmt.Fprintf(&b, "Receiving histogram: %v\n", cam.TimingReceiving.Histogram)
+	if cam.Reprojection.Calibrated {
+		_, _ = fmt.Fprintf(&b, "Reprojection: %.2fpx ± %.2fpx, max %.2fpx (%v samples)\n",
+			cam.Reprojection.Error.Mean, cam.Reprojection.Error.StdDev, cam.Reprojection.Error.Max,
+			cam.Reprojection.Error.NumSamples)
+	} else {
+		b.WriteString("Reprojection: no calibration\n")
+	}
+	_, _ = fmt.Fprintf(&b, "Visible: %v blue | %v yellow | %v balls\n",
+		cam.NumVisibleBlue, cam.NumVisibleYellow, len(cam.Balls))
+	_, _ = fmt.Fprintf(&b, "Orientation: noise %.1f° | %v flips\n", cam.OrientationNoise*180/math.Pi, cam.NumOrientationFlips)
+	_, _ = fmt.Fprintf(&b, "Robot ids: stability %v%.0f%%[-] | %v changes | %v flickers | %v phantoms\n",
+		colorTag(cam.IdStability, a.thresholds), cam.IdStability*100, cam.NumIdChanges, cam.NumIdFlickers, cam.NumIdPhantoms)
+	_, _ = fmt.Fprintf(&b, "Duplicate ids: %v active | %v total\n", cam.ActiveDuplicateIds, cam.NumDuplicateIds)
+	_, _ = fmt.Fprintf(&b, "Ball blobs: %v\n", cam.BallBlobs)
+	stationaryMode := "detected"
+	if a.snapshot.Vision.AssumeStationary {
+		stationaryMode = "assumed"
+	}
+	_, _ = fmt.Fprintf(&b, "Stationary (%v): %v\n", stationaryMode, cam.Stationary)
+	a.camera.SetText(b.String())
+
+	var rows [][]cell
+	for i, ball := range cam.Balls {
+		rows = append(rows, a.objectRow(fmt.Sprintf("ball %v", i), ball))
+	}
+	for _, robot := range cam.Robots {
+		rows = append(rows, a.objectRow(fmt.Sprintf("%v %2d", robot.Color, robot.Id), robot.ObjectSnapshot))
+	}
+	a.robots.setRows(rows)
+	a.updateRobot()
+}
+
+func (a *App) objectRow(name string, object vision.ObjectSnapshot) []cell {
+	return []cell{
+		{text: name},
+		qualityCell(object.Frames.Quality, a.thresholds),
+		floatCell("%.1f", float64(object.Frames.Fps)),
+		cell{text: object.Age.Truncate(time.Second).String(), value: float64(object.Age)},
+		floatCell("%.2f", float64(object.Position.X)),
+		floatCell("%.2f", float64(object.Position.Y)),
+	}
+}
+
+func (a *App) updateRobot() {
+	cam, ok := a.selectedCamera()
+	key := a.robots.SelectedKey()
+	if !ok || key == "" {
+		a.robot.SetText("No object selected")
+		return
+	}
+	var object vision.ObjectSnapshot
+	details := ""
+	for i, ball := range cam.Balls {
+		if key == fmt.Sprintf("ball %v", i) {
+			object = ball
+			if ball.Blob != nil {
+				details = fmt.Sprintf("\nBlob: %v", ball.Blob)
+			}
+		}
+	}
+	for _, robot := range cam.Robots {
+		if key == fmt.Sprintf("%v %2d", robot.Color, robot.Id) {
+			object = robot.ObjectSnapshot
+			details = fmt.Sprintf("\nOrientation: %.1f° | noise %.1f° | max change %.1f° | %v flips | %v jumps",
+				robot.Orientation.Orientation*180/math.Pi, robot.Orientation.Noise*180/math.Pi,
+				robot.Orientation.MaxChange*180/math.Pi, robot.Orientation.NumFlips, robot.Orientation.NumJumps) +
+				formatIds(robot, a.thresholds)
+		}
+	}
+	a.robot.SetTitle(key)
+	a.robot.SetText(fmt.Sprintf(
+		"Position: (%.3f, %.3f) m\nQuality: %v%3.0f%%[-] | %.1f fps | dt %.1fms ± %.1fms\nAge: %v | last detected %v",
+		object.Position.X, object.Position.Y,
+		colorTag(object.Frames.Quality, a.thresholds), object.Frames.Quality*100, object.Frames.Fps,
+		object.Frames.DeltaTime*1000, object.Frames.DeltaTimeSigma*1000,
+		object.Age.Truncate(time.Millisecond), object.LastDetected.Format("15:04:05.000")) + details +
+		formatStationary(object.Stationary))
+}
+
+func formatIds(robot vision.RobotSnapshot, thresholds timing.QualityThresholds) string {
+	str := fmt.Sprintf("\nIds: stability %v%.0f%%[-] | %v changes | %v flickers | %v phantoms",
+		colorTag(robot.Ids.Stability, thresholds), robot.Ids.Stability*100, robot.Ids.NumChanges, robot.Ids.NumFlickers, robot.Ids.NumPhantoms)
+	if robot.Phantom {
+		str += " | [red]phantom[-]"
+	}
+	if len(robot.Ids.Misreads) > 0 {
+		str += fmt.Sprintf("\nMisread as: %v", robot.Ids.Misreads)
+	}
+	return str
+}
+
+func formatStationary(s vision.StationarySnapshot) string {
+	if !s.Stationary {
+		return "\nNoise: moving"
+	}
+	str := fmt.Sprintf("\nNoise: σ %.1fmm (x %.1fmm, y %.1fmm) | max %.1fmm | %v samples in %v",
+		s.StdDev*1000, s.StdDevX*1000, s.StdDevY*1000, s.MaxDeviation*1000, s.NumSamples, s.Duration.Truncate(time.Millisecond))
+	if len(s.Spectrum) > 0 {
+		str += "\nSpectrum:"
+		for _, band := range s.Spectrum {
+			str += fmt.Sprintf(" %.0f-%.0fHz %.1fmm", band.MinFrequency, band.MaxFrequency, band.Amplitude*1000)
+		}
+	}
+	return str
+}
+
+func (a *App) updateCoverage() {
+	title := "Coverage of all cameras"
+	if a.coverageCamId >= 0 {
+		title = fmt.Sprintf("Coverage of camera %v", a.coverageCamId)
+	}
+	coverage, ok := a.inspector.Stats.CoverageHeatmap(a.coverageCamId)
+	a.coverage.SetTitle(title + " (c or Esc to close)")
+	if !ok {
+		a.coverage.SetText("No coverage available, geometry is missing")
+		return
+	}
+	a.coverage.SetText(tview.TranslateANSI(coverage.Terminal()))
+}
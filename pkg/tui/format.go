@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"fmt"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"github.com/gdamore/tcell/v2"
+	"time"
+)
+
+func qualityColor(quality float64, thresholds timing.QualityThresholds) tcell.Color {
+	if quality < thresholds.Low {
+		return tcell.ColorRed
+	} else if quality < thresholds.High {
+		return tcell.ColorYellow
+	}
+	return tcell.ColorGreen
+}
+
+func qualityCell(quality float64, thresholds timing.QualityThresholds) cell {
+	return cell{text: fmt.Sprintf("%3.0f%%", quality*100), value: quality, color: qualityColor(quality, thresholds)}
+}
+
+func floatCell(format string, value float64) cell {
+	return cell{text: fmt.Sprintf(format, value), value: value}
+}
+
+func intCell(value int) cell {
+	return cell{text: fmt.Sprint(value), value: float64(value)}
+}
+
+func durationCell(d time.Duration) cell {
+	return cell{text: formatDuration(d), value: float64(d)}
+}
+
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%.1fms", float64(d.Microseconds())/1000)
+}
+
+func formatTiming(s timing.TimingSnapshot) string {
+	if s.NumMeasures == 0 {
+		return "no measures"
+	}
+	str := fmt.Sprintf("min %v | median %v | avg %v ± %v | max %v",
+		formatDuration(s.Min), formatDuration(s.Median), formatDuration(s.Avg), formatDuration(s.StdDev), formatDuration(s.Max))
+	for _, p := range s.Percentiles {
+		str += fmt.Sprintf(" | %v %v", p.Name, formatDuration(p.Duration))
+	}
+	return str
+}
+
+// colorTag returns a tview color tag for a quality value
+func colorTag(quality float64, thresholds timing.QualityThresholds) string {
+	return "[" + qualityColor(quality, thresholds).String() + "]"
+}
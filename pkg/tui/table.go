@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"sort"
+	"strings"
+)
+
+// cell is a table cell with a display text and a value for sorting
+type cell struct {
+	text  string
+	value float64
+	// color is an optional text color
+	color tcell.Color
+}
+
+// sortableTable is a table with a header row that can be sorted by any column and filtered by text
+type sortableTable struct {
+	*tview.Table
+	headers    []string
+	sortColumn int
+	descending bool
+	filter     string
+	// keys holds the key of each displayed row, the key of a row is the text of its first cell
+	keys []string
+}
+
+func newSortableTable(title string, headers ...string) (t *sortableTable) {
+	t = new(sortableTable)
+	t.Table = tview.NewTable().SetSelectable(true, false).SetFixed(1, 0)
+	t.SetBorder(true).SetTitle(title)
+	t.headers = headers
+	return t
+}
+
+// nextSortColumn sorts by the next column, starting again at the first column after the last one
+func (t *sortableTable) nextSortColumn() {
+	t.sortColumn = (t.sortColumn + 1) % len(t.headers)
+}
+
+func (t *sortableTable) reverse() {
+	t.descending = !t.descending
+}
+
+// SelectedKey returns the key of the selected row or an empty string if no row is selected
+func (t *sortableTable) SelectedKey() string {
+	row, _ := t.GetSelection()
+	if row < 1 || row > len(t.keys) {
+		return ""
+	}
+	return t.keys[row-1]
+}
+
+// setRows replaces the content of the table while keeping the selected row
+func (t *sortableTable) setRows(rows [][]cell) {
+	selectedKey := t.SelectedKey()
+
+	var filtered [][]cell
+	for _, row := range rows {
+		if t.matches(row) {
+			filtered = append(filtered, row)
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		a := filtered[i][t.sortColumn]
+		b := filtered[j][t.sortColumn]
+		if t.descending {
+			a, b = b, a
+		}
+		if a.value != b.value {
+			return a.value < b.value
+		}
+		return a.text < b.text
+	})
+
+	t.Clear()
+	for c, header := range t.headers {
+		if c == t.sortColumn {
+			if t.descending {
+				header += "▼"
+			} else {
+				header += "▲"
+			}
+		}
+		t.SetCell(0, c, tview.NewTableCell(header).SetSelectable(false).SetAttributes(tcell.AttrBold).SetExpansion(1))
+	}
+	t.keys = make([]string, len(filtered))
+	selectedRow := 1
+	for r, row := range filtered {
+		t.keys[r] = row[0].text
+		if row[0].text == selectedKey {
+			selectedRow = r + 1
+		}
+		for c, rowCell := range row {
+			tableCell := tview.NewTableCell(tview.Escape(rowCell.text)).SetExpansion(1)
+			if rowCell.color != tcell.ColorDefault {
+				tableCell.SetTextColor(rowCell.color)
+			}
+			t.SetCell(r+1, c, tableCell)
+		}
+	}
+	if len(filtered) > 0 {
+		t.Select(selectedRow, 0)
+	}
+}
+
+func (t *sortableTable) matches(row []cell) bool {
+	if t.filter == "" {
+		return true
+	}
+	filter := strings.ToLower(t.filter)
+	for _, rowCell := range row {
+		if strings.Contains(strings.ToLower(rowCell.text), filter) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSortableTable_SetRows(t *testing.T) {
+	table := newSortableTable("test", "Camera", "FPS")
+	rows := [][]cell{
+		{intCell(0), floatCell("%.1f", 70)},
+		{intCell(1), floatCell("%.1f", 50)},
+		{intCell(2), floatCell("%.1f", 60)},
+	}
+
+	table.setRows(rows)
+	if !reflect.DeepEqual(table.keys, []string{"0", "1", "2"}) {
+		t.Errorf("Unexpected order by first column: %v", table.keys)
+	}
+
+	table.Select(3, 0)
+	table.nextSortColumn()
+	table.reverse()
+	table.setRows(rows)
+	if !reflect.DeepEqual(table.keys, []string{"0", "2", "1"}) {
+		t.Errorf("Unexpected descending order by second column: %v", table.keys)
+	}
+	if table.SelectedKey() != "2" {
+		t.Errorf("Expected selection to be kept, got %v", table.SelectedKey())
+	}
+
+	table.filter = "1"
+	table.setRows(rows)
+	if !reflect.DeepEqual(table.keys, []string{"1"}) {
+		t.Errorf("Unexpected filtered rows: %v", table.keys)
+	}
+}
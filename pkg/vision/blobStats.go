@@ -0,0 +1,176 @@
+package vision
+
+import (
+	"fmt"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"math"
+	"time"
+)
+
+// ballRadius is the radius of the ball in millimeters
+const ballRadius = 21.5
+
+// BlobStats collects the confidence, blob area and height of ball detections within a time window
+type BlobStats struct {
+	samples *timing.TimeWindow[blobSample]
+	// NumHeights counts all detections with a height estimate above the ground
+	NumHeights int
+}
+
+type blobSample struct {
+	confidence float64
+	// area is the blob size in pixels
+	area float64
+	// areaRatio is the area relative to the expected area of the ball at its distance to the camera, zero if not calibrated
+	areaRatio float64
+	// height is in meters
+	height float64
+}
+
+// BlobSnapshot describes the ball detections within the time window
+type BlobSnapshot struct {
+	NumSamples int                  `json:"numSamples"`
+	Confidence DistributionSnapshot `json:"confidence"`
+	Area       DistributionSnapshot `json:"area"`
+	// AreaRatio is only available with a camera calibration
+	AreaRatio DistributionSnapshot `json:"areaRatio"`
+	// Height only includes detections above the ground, in meters
+	Height     DistributionSnapshot `json:"height"`
+	NumHeights int                  `json:"numHeights"`
+	// ConfidenceTrend and AreaRatioTrend are the change per minute by linear regression,
+	// only available if the samples cover at least half of the time window
+	ConfidenceTrend float64 `json:"confidenceTrend"`
+	AreaRatioTrend  float64 `json:"areaRatioTrend"`
+}
+
+// DistributionSnapshot summarizes a set of values
+type DistributionSnapshot struct {
+	NumSamples int     `json:"numSamples"`
+	Min        float64 `json:"min"`
+	Max        float64 `json:"max"`
+	Mean       float64 `json:"mean"`
+	StdDev     float64 `json:"stdDev"`
+}
+
+func NewBlobStats(timeWindow time.Duration) (s *BlobStats) {
+	s = new(BlobStats)
+	s.samples = timing.NewTimeWindow[blobSample](timeWindow)
+	return s
+}
+
+// Add adds a ball detection with the height in meters and the area ratio, which is zero if unknown
+func (s *BlobStats) Add(t time.Time, confidence float64, area float64, areaRatio float64, height float64) {
+	s.samples.Add(t, blobSample{confidence: confidence, area: area, areaRatio: areaRatio, height: height})
+	if height > 0 {
+		s.NumHeights++
+	}
+	s.Prune(t)
+}
+
+func (s *BlobStats) Prune(t time.Time) {
+	s.samples.Prune(t)
+}
+
+func (s *BlobStats) Clear() {
+	s.samples.Clear()
+}
+
+func (s *BlobStats) Snapshot() (snapshot BlobSnapshot) {
+	var confidences, areas, areaRatios, heights, confidenceTimes, areaRatioTimes []float64
+	for t, sample := range s.samples.All() {
+		tSample := t.Sub(s.samples.Time(0)).Minutes()
+		confidences = append(confidences, sample.confidence)
+		confidenceTimes = append(confidenceTimes, tSample)
+		areas = append(areas, sample.area)
+		if sample.areaRatio > 0 {
+			areaRatios = append(areaRatios, sample.areaRatio)
+			areaRatioTimes = append(areaRatioTimes, tSample)
+		}
+		if sample.height > 0 {
+			heights = append(heights, sample.height)
+		}
+	}
+	snapshot.NumSamples = s.samples.Len()
+	snapshot.Confidence = newDistributionSnapshot(confidences)
+	snapshot.Area = newDistributionSnapshot(areas)
+	snapshot.AreaRatio = newDistributionSnapshot(areaRatios)
+	snapshot.Height = newDistributionSnapshot(heights)
+	snapshot.NumHeights = s.NumHeights
+	if s.samples.Len() > 0 && s.samples.Span() >= s.samples.Duration/2 {
+		// short periods would be extrapolated too much
+		snapshot.ConfidenceTrend = slope(confidenceTimes, confidences)
+		snapshot.AreaRatioTrend = slope(areaRatioTimes, areaRatios)
+	}
+	return
+}
+
+func (s *BlobStats) String() string {
+	return s.Snapshot().String()
+}
+
+func (s BlobSnapshot) String() string {
+	str := fmt.Sprintf("confidence %v (%+.2f/min) | area %.0fpx ± %.0fpx",
+		s.Confidence, s.ConfidenceTrend, s.Area.Mean, s.Area.StdDev)
+	if s.AreaRatio.NumSamples > 0 {
+		str += fmt.Sprintf(" | %.0f%% of expected (%+.0f%%/min)", s.AreaRatio.Mean*100, s.AreaRatioTrend*100)
+	}
+	if s.NumHeights > 0 {
+		str += fmt.Sprintf(" | %v above ground, max %.2fm", s.NumHeights, s.Height.Max)
+	}
+	return str
+}
+
+func (s DistributionSnapshot) String() string {
+	return fmt.Sprintf("%.2f ± %.2f [%.2f, %.2f]", s.Mean, s.StdDev, s.Min, s.Max)
+}
+
+func newDistributionSnapshot(values []float64) (snapshot DistributionSnapshot) {
+	if len(values) == 0 {
+		return
+	}
+	snapshot.NumSamples = len(values)
+	snapshot.Min = values[0]
+	snapshot.Max = values[0]
+	var sum, sqSum float64
+	for _, v := range values {
+		snapshot.Min = math.Min(snapshot.Min, v)
+		snapshot.Max = math.Max(snapshot.Max, v)
+		sum += v
+		sqSum += v * v
+	}
+	n := float64(len(values))
+	snapshot.Mean = sum / n
+	snapshot.StdDev = math.Sqrt(math.Max(0, sqSum/n-snapshot.Mean*snapshot.Mean))
+	return
+}
+
+// slope returns the slope of a linear regression of y over x
+func slope(x []float64, y []float64) float64 {
+	n := float64(len(x))
+	if n < 2 {
+		return 0
+	}
+	var sumX, sumY, sumXX, sumXY float64
+	for i := range x {
+		sumX += x[i]
+		sumY += y[i]
+		sumXX += x[i] * x[i]
+		sumXY += x[i] * y[i]
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+// expectedBallArea returns the area of the ball in pixels at the given world position in millimeters,
+// based on the distance to the camera and ignoring the distortion
+func expectedBallArea(model *CameraModel, pos Vector3) float64 {
+	d := distance(model.WorldPosition(), pos)
+	if d <= ballRadius {
+		return 0
+	}
+	radius := model.FocalLength * ballRadius / d
+	return math.Pi * radius * radius
+}
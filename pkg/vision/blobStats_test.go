@@ -0,0 +1,51 @@
+package vision
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBlobStats_Snapshot(t *testing.T) {
+	stats := NewBlobStats(time.Minute)
+	tStart := time.Unix(1000, 0)
+	for i := 0; i <= 60; i++ {
+		// the confidence falls by 0.1 and the area by 20% within a minute
+		confidence := 0.9 - float64(i)*0.1/60
+		areaRatio := 1 - float64(i)*0.2/60
+		height := 0.0
+		if i%10 == 0 {
+			height = 0.1
+		}
+		stats.Add(tStart.Add(time.Duration(i)*time.Second), confidence, 50*areaRatio, areaRatio, height)
+	}
+
+	s := stats.Snapshot()
+	if s.NumSamples != 61 || s.NumHeights != 7 || s.Height.NumSamples != 7 || s.Height.Max != 0.1 {
+		t.Errorf("Expected 61 samples with 7 heights of 0.1m, got %v", s)
+	}
+	if math.Abs(s.Confidence.Min-0.8) > 1e-9 || math.Abs(s.Confidence.Max-0.9) > 1e-9 || math.Abs(s.Confidence.Mean-0.85) > 1e-9 {
+		t.Errorf("Unexpected confidence %v", s.Confidence)
+	}
+	if math.Abs(s.ConfidenceTrend+0.1) > 1e-9 || math.Abs(s.AreaRatioTrend+0.2) > 1e-9 {
+		t.Errorf("Expected trends of -0.1 and -0.2 per minute, got %v and %v", s.ConfidenceTrend, s.AreaRatioTrend)
+	}
+	if math.Abs(s.Area.Mean-45) > 1e-9 {
+		t.Errorf("Expected a mean area of 45, got %v", s.Area.Mean)
+	}
+
+	stats.Prune(tStart.Add(3 * time.Minute))
+	if s := stats.Snapshot(); s.NumSamples != 0 || s.NumHeights != 7 {
+		t.Errorf("Expected no samples, but still 7 heights after pruning, got %v", s)
+	}
+}
+
+func TestExpectedBallArea(t *testing.T) {
+	model := NewCameraModel(testCalibration(0, 0))
+	// the camera is 4m above the field center with a focal length of 500px
+	area := expectedBallArea(&model, Vector3{})
+	radius := 500 * ballRadius / 4000
+	if math.Abs(area-math.Pi*radius*radius) > 1e-6 {
+		t.Errorf("Expected an area of %v, got %v", math.Pi*radius*radius, area)
+	}
+}
@@ -0,0 +1,105 @@
+package vision
+
+import "math"
+
+// Vector3 is a position in 3d space in millimeters
+type Vector3 struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
+}
+
+// CameraModel is the pinhole camera model with radial distortion as used by ssl-vision
+type CameraModel struct {
+	FocalLength     float64
+	PrincipalPointX float64
+	PrincipalPointY float64
+	Distortion      float64
+	// Rotation from world to camera coordinates
+	Rotation [3][3]float64
+	// Translation from world to camera coordinates
+	Translation Vector3
+}
+
+func NewCameraModel(calib *SSL_GeometryCameraCalibration) (m CameraModel) {
+	m.FocalLength = float64(calib.GetFocalLength())
+	m.PrincipalPointX = float64(calib.GetPrincipalPointX())
+	m.PrincipalPointY = float64(calib.GetPrincipalPointY())
+	m.Distortion = float64(calib.GetDistortion())
+	m.Rotation = rotationMatrix(
+		float64(calib.GetQ0()),
+		float64(calib.GetQ1()),
+		float64(calib.GetQ2()),
+		float64(calib.GetQ3()))
+	m.Translation = Vector3{
+		X: float64(calib.GetTx()),
+		Y: float64(calib.GetTy()),
+		Z: float64(calib.GetTz()),
+	}
+	return
+}
+
+// rotationMatrix converts a quaternion with the vector part (x, y, z) and the scalar part w to a rotation matrix
+func rotationMatrix(x, y, z, w float64) (r [3][3]float64) {
+	norm := math.Sqrt(x*x + y*y + z*z + w*w)
+	if norm == 0 {
+		return [3][3]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	}
+	x, y, z, w = x/norm, y/norm, z/norm, w/norm
+	r[0] = [3]float64{1 - 2*(y*y+z*z), 2 * (x*y - z*w), 2 * (x*z + y*w)}
+	r[1] = [3]float64{2 * (x*y + z*w), 1 - 2*(x*x+z*z), 2 * (y*z - x*w)}
+	r[2] = [3]float64{2 * (x*z - y*w), 2 * (y*z + x*w), 1 - 2*(x*x+y*y)}
+	return
+}
+
+// WorldPosition calculates the position of the camera in world coordinates
+func (m *CameraModel) WorldPosition() (p Vector3) {
+	t := m.Translation
+	r := m.Rotation
+	// p = -R^T * t
+	p.X = -(r[0][0]*t.X + r[1][0]*t.Y + r[2][0]*t.Z)
+	p.Y = -(r[0][1]*t.X + r[1][1]*t.Y + r[2][1]*t.Z)
+	p.Z = -(r[0][2]*t.X + r[1][2]*t.Y + r[2][2]*t.Z)
+	return
+}
+
+// CameraWorldPosition returns the camera position reported by ssl-vision, or calculates it if not available
+func CameraWorldPosition(calib *SSL_GeometryCameraCalibration) Vector3 {
+	if calib.DerivedCameraWorldTx != nil && calib.DerivedCameraWorldTy != nil && calib.DerivedCameraWorldTz != nil {
+		return Vector3{
+			X: float64(*calib.DerivedCameraWorldTx),
+			Y: float64(*calib.DerivedCameraWorldTy),
+			Z: float64(*calib.DerivedCameraWorldTz),
+		}
+	}
+	model := NewCameraModel(calib)
+	return model.WorldPosition()
+}
+
+// FieldToImage projects a point in world coordinates to image coordinates in pixels.
+// ok is false if the point is behind the camera.
+func (m *CameraModel) FieldToImage(p Vector3) (x float64, y float64, ok bool) {
+	r := m.Rotation
+	cx := r[0][0]*p.X + r[0][1]*p.Y + r[0][2]*p.Z + m.Translation.X
+	cy := r[1][0]*p.X + r[1][1]*p.Y + r[1][2]*p.Z + m.Translation.Y
+	cz := r[2][0]*p.X + r[2][1]*p.Y + r[2][2]*p.Z + m.Translation.Z
+	if cz <= 0 {
+		return 0, 0, false
+	}
+
+	// project to the image plane
+	ax := cx / cz
+	ay := cy / cz
+
+	// apply radial distortion
+	ru := math.Sqrt(ax*ax + ay*ay)
+	if ru > 0 {
+		rd := ru * (1 + m.Distortion*ru*ru)
+		ax *= rd / ru
+		ay *= rd / ru
+	}
+
+	x = m.FocalLength*ax + m.PrincipalPointX
+	y = m.FocalLength*ay + m.PrincipalPointY
+	return x, y, true
+}
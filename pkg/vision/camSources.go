@@ -0,0 +1,164 @@
+package vision
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CamKey identifies the statistics of a camera published by a certain source host
+type CamKey struct {
+	Source string
+	CamId  int
+}
+
+// CamSources tracks the hosts that publish each camera and detects hosts that publish the same camera
+type CamSources struct {
+	// Primary is the source of each camera that is used for the camera overlap and coverage statistics,
+	// which is the first source that published the camera
+	Primary map[int]string
+	// NumSwitches counts the frames of each camera that were published by another host than the previous frame
+	NumSwitches map[int]int
+	lastFrame   map[CamKey]time.Time
+	lastSource  map[int]string
+	conflicts   map[int]bool
+	timeWindow  time.Duration
+}
+
+// CamSourcesSnapshot is a copy of the sources of a camera
+type CamSourcesSnapshot struct {
+	CameraId int    `json:"cameraId"`
+	Primary  string `json:"primary"`
+	// Active are the sources that published the camera within the time window
+	Active      []string `json:"active"`
+	NumSwitches int      `json:"numSwitches"`
+	Conflict    bool     `json:"conflict"`
+}
+
+func NewCamSources(timeWindow time.Duration) (s *CamSources) {
+	s = new(CamSources)
+	s.Primary = map[int]string{}
+	s.NumSwitches = map[int]int{}
+	s.lastFrame = map[CamKey]time.Time{}
+	s.lastSource = map[int]string{}
+	s.conflicts = map[int]bool{}
+	s.timeWindow = timeWindow
+	return s
+}
+
+// Add registers a frame of a camera received at the given time and returns log messages about changed sources
+func (s *CamSources) Add(key CamKey, t time.Time) (events []string) {
+	s.lastFrame[key] = t
+	s.prune(t)
+
+	primary, ok := s.Primary[key.CamId]
+	if !ok {
+		s.Primary[key.CamId] = key.Source
+	} else if primary != key.Source && !s.isActive(CamKey{Source: primary, CamId: key.CamId}, t) {
+		s.Primary[key.CamId] = key.Source
+		events = append(events, fmt.Sprintf("Camera %d is now published by %v instead of %v", key.CamId, key.Source, primary))
+	}
+
+	if last, ok := s.lastSource[key.CamId]; ok && last != key.Source {
+		s.NumSwitches[key.CamId]++
+	}
+	s.lastSource[key.CamId] = key.Source
+
+	active := s.ActiveSources(key.CamId, t)
+	if len(active) > 1 && !s.conflicts[key.CamId] {
+		s.conflicts[key.CamId] = true
+		events = append(events, fmt.Sprintf("Camera %d is published by multiple hosts: %v", key.CamId, strings.Join(active, ", ")))
+	} else if len(active) == 1 && s.conflicts[key.CamId] {
+		s.conflicts[key.CamId] = false
+		events = append(events, fmt.Sprintf("Camera %d is only published by %v again", key.CamId, key.Source))
+	}
+	return
+}
+
+// prune forgets the sources that did not publish a camera within the time window
+func (s *CamSources) prune(t time.Time) {
+	for key := range s.lastFrame {
+		if !s.isActive(key, t) {
+			delete(s.lastFrame, key)
+		}
+	}
+}
+
+func (s *CamSources) isActive(key CamKey, t time.Time) bool {
+	lastFrame, ok := s.lastFrame[key]
+	return ok && t.Sub(lastFrame) < s.timeWindow
+}
+
+// IsPrimary returns true, if the source is the primary source of the camera
+func (s *CamSources) IsPrimary(key CamKey) bool {
+	return s.Primary[key.CamId] == key.Source
+}
+
+// IsPrimarySource returns true, if the source is the primary source of any camera or if no camera is known yet
+func (s *CamSources) IsPrimarySource(source string) bool {
+	if len(s.Primary) == 0 {
+		return true
+	}
+	for _, primary := range s.Primary {
+		if primary == source {
+			return true
+		}
+	}
+	return false
+}
+
+// ActiveSources returns the sorted sources that published the camera within the time window
+func (s *CamSources) ActiveSources(camId int, t time.Time) (sources []string) {
+	for key := range s.lastFrame {
+		if key.CamId == camId && s.isActive(key, t) {
+			sources = append(sources, key.Source)
+		}
+	}
+	sort.Strings(sources)
+	return
+}
+
+func (s *CamSources) Snapshot(t time.Time) (snapshot []CamSourcesSnapshot) {
+	snapshot = []CamSourcesSnapshot{}
+	for _, camId := range sortedKeys(s.Primary) {
+		active := s.ActiveSources(camId, t)
+		if active == nil {
+			active = []string{}
+		}
+		snapshot = append(snapshot, CamSourcesSnapshot{
+			CameraId:    camId,
+			Primary:     s.Primary[camId],
+			Active:      active,
+			NumSwitches: s.NumSwitches[camId],
+			Conflict:    s.conflicts[camId],
+		})
+	}
+	return
+}
+
+// camName returns the name of a camera for log messages, including the source if it is not the primary source
+func (s *CamSources) camName(key CamKey) string {
+	if s.IsPrimary(key) {
+		return fmt.Sprintf("Camera %d", key.CamId)
+	}
+	return fmt.Sprintf("Camera %d from %v", key.CamId, key.Source)
+}
+
+// sortedCamKeys returns the keys of the map sorted by camera id with the primary source first and then by source
+func (s *CamSources) sortedCamKeys(m map[CamKey]*CamStats) []CamKey {
+	keys := make([]CamKey, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].CamId != keys[j].CamId {
+			return keys[i].CamId < keys[j].CamId
+		}
+		if primaryI, primaryJ := s.IsPrimary(keys[i]), s.IsPrimary(keys[j]); primaryI != primaryJ {
+			return primaryI
+		}
+		return keys[i].Source < keys[j].Source
+	})
+	return keys
+}
@@ -0,0 +1,42 @@
+package vision
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCamSources_Add(t *testing.T) {
+	sources := NewCamSources(time.Second)
+	tStart := time.Unix(1000, 0)
+	primary := CamKey{Source: "10.0.0.1", CamId: 2}
+	other := CamKey{Source: "10.0.0.99", CamId: 2}
+
+	if events := sources.Add(primary, tStart); len(events) != 0 {
+		t.Errorf("Unexpected events for the first source: %v", events)
+	}
+	if events := sources.Add(other, tStart.Add(10*time.Millisecond)); len(events) != 1 {
+		t.Errorf("Expected a conflict event, got %v", events)
+	}
+	sources.Add(primary, tStart.Add(20*time.Millisecond))
+	if !sources.IsPrimary(primary) || sources.IsPrimary(other) {
+		t.Errorf("Expected %v to stay the primary source", primary.Source)
+	}
+	if sources.NumSwitches[2] != 2 {
+		t.Errorf("Expected 2 source switches, got %v", sources.NumSwitches[2])
+	}
+	snapshot := sources.Snapshot(tStart.Add(20 * time.Millisecond))
+	if len(snapshot) != 1 || !snapshot[0].Conflict || len(snapshot[0].Active) != 2 {
+		t.Errorf("Expected a conflict with 2 active sources, got %+v", snapshot)
+	}
+
+	// the primary source stops publishing the camera
+	if events := sources.Add(other, tStart.Add(2*time.Second)); len(events) != 2 {
+		t.Errorf("Expected events for the new primary source and the resolved conflict, got %v", events)
+	}
+	if !sources.IsPrimary(other) {
+		t.Errorf("Expected %v to be the primary source", other.Source)
+	}
+	if _, ok := sources.lastFrame[primary]; ok || len(sources.lastFrame) != 1 {
+		t.Errorf("Expected the silent source to be pruned, got %v", sources.lastFrame)
+	}
+}
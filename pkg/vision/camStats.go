@@ -12,20 +12,39 @@ const maxBotVel = 6.0
 
 type CamStats struct {
 	FrameStats       *timing.FrameStats
+	Sequence         timing.Sequence
+	Capture          *CaptureStats
 	Robots           map[TeamColor][]*RobotStats
 	Balls            []*ObjectStats
 	TimingProcessing *timing.Timing
 	TimingReceiving  *timing.Timing
-	statsConfig      StatsConfig
+	Reprojection     *ReprojectionStats
+	// Blob collects the detections of all balls of the camera
+	Blob *BlobStats
+	// NumOrientationFlips counts the orientation flips of all robots of the camera
+	NumOrientationFlips int
+	// NumIdChanges, NumIdFlickers and NumIdPhantoms count the id events of all robots of the camera, see IdStats
+	NumIdChanges  int
+	NumIdFlickers int
+	NumIdPhantoms int
+	statsConfig   StatsConfig
+	frameOrderLog frameOrderLog
 }
 
 func NewCamStats(statsConfig StatsConfig) (s *CamStats) {
 	s = new(CamStats)
-	s.FrameStats = timing.NewFrameStats(statsConfig.TimeWindowQualityCam)
+	s.FrameStats = timing.NewFrameStats(statsConfig.TimeWindowQualityCam, statsConfig.Clock)
+	s.FrameStats.QualityThresholds = statsConfig.QualityThresholds
 	s.Robots = map[TeamColor][]*RobotStats{}
+	s.Capture = NewCaptureStats(statsConfig.TimeWindowCapture, statsConfig.Clock)
+	s.Blob = NewBlobStats(statsConfig.TimeWindowBlob)
 	s.statsConfig = statsConfig
-	s.TimingProcessing = timing.NewTiming(statsConfig.TimeWindowQualityCam)
-	s.TimingReceiving = timing.NewTiming(statsConfig.TimeWindowQualityCam)
+	s.TimingProcessing = timing.NewTiming(statsConfig.TimeWindowQualityCam, statsConfig.Clock)
+	s.TimingReceiving = timing.NewTiming(statsConfig.TimeWindowQualityCam, statsConfig.Clock)
+	s.TimingProcessing.Distribution = statsConfig.Distribution
+	s.TimingReceiving.Distribution = statsConfig.Distribution
+	s.Capture.Interval.Distribution = statsConfig.Distribution
+	s.Reprojection = NewReprojectionStats(statsConfig.TimeWindowReprojection)
 
 	return s
 }
@@ -37,21 +56,32 @@ func (s CamStats) String() string {
 		colorizeByTeam(s.NumVisibleRobots(TeamYellow), TeamYellow),
 		len(s.Balls))
 	str += fmt.Sprintf("Processing Time: %v\n Receiving Time: %v\n", s.TimingProcessing, s.TimingReceiving)
+	str += fmt.Sprintf("      Receiving: %v\n", s.TimingReceiving.Histogram())
+	str += fmt.Sprintf("       Sequence: %v\n", &s.Sequence)
+	str += fmt.Sprintf("        Capture: %v\n", s.Capture)
+	str += fmt.Sprintf("   Reprojection: %v\n", s.Reprojection)
+	str += fmt.Sprintf("     Ball blobs: %v\n", s.Blob)
+	str += fmt.Sprintf("      Robot ids: stability %.0f%% | %v changes | %v flickers | %v phantoms\n",
+		s.IdStability()*100, s.NumIdChanges, s.NumIdFlickers, s.NumIdPhantoms)
+
+	str += fmt.Sprintf("     Stationary: %v\n", camStationarySnapshot(s.stationarySnapshots()))
 
 	str += "Balls: \n"
 	for _, ball := range s.Balls {
-		str += fmt.Sprintf("%v\n", ball)
+		str += fmt.Sprintf("%v | %v | %v\n", ball, ball.Blob, s.stationarySnapshot(ball))
 	}
 	str += "Robots: \n"
 
 	for _, robot := range s.sortedRobotStats() {
-		str += fmt.Sprintf("%v %v\n", robot.Id, robot)
+		str += fmt.Sprintf("%v %v | %v | %v | %v\n", robot.Id, robot, robot.Orientation, robot.Ids, s.stationarySnapshot(robot.ObjectStats))
 	}
 	return str
 }
 
 func (s *CamStats) Clear() {
 	s.FrameStats.Clear()
+	s.Capture.Clear()
+	s.Blob.Clear()
 	for teamColor := range s.Robots {
 		for _, robot := range s.Robots[teamColor] {
 			robot.Clear()
@@ -62,6 +92,30 @@ func (s *CamStats) Clear() {
 	}
 }
 
+// SetAssumeStationary sets whether all objects are treated as stationary and restarts the noise measurement
+func (s *CamStats) SetAssumeStationary(assumeStationary bool) {
+	s.statsConfig.AssumeStationary = assumeStationary
+	for _, object := range s.objects() {
+		object.Stationary.Clear()
+	}
+}
+
+func (s *CamStats) stationarySnapshots() (snapshots []StationarySnapshot) {
+	for _, object := range s.objects() {
+		snapshots = append(snapshots, s.stationarySnapshot(object))
+	}
+	return
+}
+
+// objects returns the statistics of all balls and robots
+func (s *CamStats) objects() (objects []*ObjectStats) {
+	objects = append(objects, s.Balls...)
+	for _, robot := range s.sortedRobotStats() {
+		objects = append(objects, robot.ObjectStats)
+	}
+	return
+}
+
 func (s *CamStats) Merge() {
 	s.Balls = mergeObjects(s.Balls)
 	s.Robots[TeamYellow] = mergeRobots(s.Robots[TeamYellow])
@@ -124,6 +178,8 @@ func matchingObject(mergedBalls []*ObjectStats, ball *ObjectStats) *ObjectStats
 
 func (s *CamStats) Prune(tSent time.Time) {
 	s.FrameStats.Prune(tSent.Add(-s.statsConfig.TimeWindowQualityCam))
+	s.Reprojection.Prune(tSent)
+	s.Blob.Prune(tSent)
 	for teamColor := range s.Robots {
 		var newRobots []*RobotStats
 		for _, robot := range s.Robots[teamColor] {
@@ -147,7 +203,7 @@ func (s *CamStats) Prune(tSent time.Time) {
 func (s *CamStats) NumVisibleRobots(teamColor TeamColor) int {
 	numRobots := 0
 	for _, robot := range s.Robots[teamColor] {
-		if robot.FrameStats.Quality() > 0.5 {
+		if robot.FrameStats.Quality() > s.statsConfig.VisibleRobotQuality {
 			numRobots++
 		}
 	}
@@ -168,33 +224,101 @@ func (s *CamStats) GetBallStats(tSent time.Time, newPos Position2d) (ballStats *
 		}
 	}
 	if ballStats == nil {
-		ballStats = NewObjectStats(Detection{Pos: newPos, Time: tSent}, s.statsConfig.TimeWindowQualityBall)
+		ballStats = NewObjectStats(Detection{Pos: newPos, Time: tSent}, s.statsConfig.TimeWindowQualityBall, s.statsConfig.Clock)
+		ballStats.FrameStats.QualityThresholds = s.statsConfig.QualityThresholds
+		ballStats.Stationary = NewStationaryStats(s.statsConfig.TimeWindowStationary, s.statsConfig.StationaryRadius)
+		ballStats.Blob = NewBlobStats(s.statsConfig.TimeWindowBlob)
 		s.Balls = append(s.Balls, ballStats)
 	}
 	return
 }
 
 func (s *CamStats) GetRobotStats(robotId RobotId, tSent time.Time, robotPos Position2d) (robotStats *RobotStats) {
+	// prefer the track with the same id, so that a misread id does not take over the track of another robot
+	robotStats = s.closestRobot(robotId.Color, tSent, robotPos, func(robot *RobotStats) bool { return robot.Id == robotId })
+	if robotStats == nil {
+		robotStats = s.closestRobot(robotId.Color, tSent, robotPos, func(*RobotStats) bool { return true })
+	}
+	if robotStats == nil {
+		robotStats = new(RobotStats)
+		*robotStats = NewRobotStats(robotId, Detection{Pos: robotPos, Time: tSent}, s.statsConfig.TimeWindowQualityRobot, s.statsConfig.Clock)
+		robotStats.FrameStats.QualityThresholds = s.statsConfig.QualityThresholds
+		robotStats.Stationary = NewStationaryStats(s.statsConfig.TimeWindowStationary, s.statsConfig.StationaryRadius)
+		robotStats.Ids = NewIdStats(s.statsConfig.TimeWindowRobotIds)
+		s.Robots[robotId.Color] = append(s.Robots[robotId.Color], robotStats)
+	}
+	return
+}
+
+// closestRobot returns the matching track that requires the lowest velocity to reach the given position
+func (s *CamStats) closestRobot(teamColor TeamColor, tSent time.Time, robotPos Position2d, matches func(robot *RobotStats) bool) (robotStats *RobotStats) {
 	minV := maxBotVel
-	for _, robot := range s.Robots[robotId.Color] {
+	for _, robot := range s.Robots[teamColor] {
 		if robot.LastDetection.Time == tSent {
 			// already got a sample
 			continue
 		}
+		if !matches(robot) {
+			continue
+		}
 		v := robot.Velocity(tSent, robotPos)
 		if v < minV {
 			robotStats = robot
 			minV = v
 		}
 	}
-	if robotStats == nil {
-		robotStats = new(RobotStats)
-		*robotStats = NewRobotStats(robotId, Detection{Pos: robotPos, Time: tSent}, s.statsConfig.TimeWindowQualityRobot)
-		s.Robots[robotId.Color] = append(s.Robots[robotId.Color], robotStats)
-	}
 	return
 }
 
+// RobotAtPosition returns another robot track of any team that was detected at the position of the given robot in the same frame
+func (s *CamStats) RobotAtPosition(robot *RobotStats) *RobotStats {
+	for _, robots := range s.Robots {
+		for _, other := range robots {
+			if other != robot && other.LastDetection.Time == robot.LastDetection.Time &&
+				other.LastDetection.Pos.DistanceTo(robot.LastDetection.Pos) < maxPhantomDistance {
+				return other
+			}
+		}
+	}
+	return nil
+}
+
+// IdStability returns the fraction of detections of all robots with the most frequent id of their track
+func (s *CamStats) IdStability() float64 {
+	numDominant := 0
+	numDetections := 0
+	for _, robots := range s.Robots {
+		for _, robot := range robots {
+			if robot.Phantom {
+				continue
+			}
+			_, count := robot.Ids.Dominant()
+			numDominant += count
+			numDetections += robot.Ids.samples.Len()
+		}
+	}
+	if numDetections == 0 {
+		return 1
+	}
+	return float64(numDominant) / float64(numDetections)
+}
+
+// OrientationNoise returns the mean orientation noise of all robots of the camera in radians
+func (s *CamStats) OrientationNoise() float64 {
+	sum := 0.0
+	n := 0
+	for _, robot := range s.sortedRobotStats() {
+		if robot.Orientation.hasLast {
+			sum += robot.Orientation.Noise()
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return sum / float64(n)
+}
+
 func (s *CamStats) sortedRobotStats() []*RobotStats {
 	var robots []*RobotStats
 
@@ -0,0 +1,180 @@
+package vision
+
+import (
+	"fmt"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"math"
+	"sort"
+	"time"
+)
+
+// minCaptureIntervals is the number of capture intervals required to detect frames that are missing by capture time
+const minCaptureIntervals = 10
+
+// CaptureStats analyses the capture timestamps of a camera
+type CaptureStats struct {
+	// Interval is the time between the capture of two consecutive frame numbers
+	Interval *timing.Timing
+	// NumNonMonotonic counts frames with a capture time that is not after the capture time of the previous frame
+	NumNonMonotonic int
+	// NumCaptureGaps counts capture intervals that are longer than the frame number gap suggests
+	NumCaptureGaps int
+	// NumMissingCaptures is the number of frames that are missing by capture time, but not by frame number
+	NumMissingCaptures int
+	lastCapture        time.Time
+	// offsets holds the time between capturing and receiving each frame within the time window by receive time
+	offsets *timing.TimeWindow[time.Duration]
+}
+
+type CaptureSnapshot struct {
+	Interval           timing.TimingSnapshot `json:"interval"`
+	NumNonMonotonic    int                   `json:"numNonMonotonic"`
+	NumCaptureGaps     int                   `json:"numCaptureGaps"`
+	NumMissingCaptures int                   `json:"numMissingCaptures"`
+	// Offset is the mean time between capturing and receiving a frame
+	Offset time.Duration `json:"offset"`
+	// Drift is the change of the offset in microseconds per second, which is the drift of the capture clock relative to the receiving host in ppm
+	Drift float64 `json:"drift"`
+	// RelativeOffset and RelativeDrift are relative to the median of all cameras
+	RelativeOffset time.Duration `json:"relativeOffset"`
+	RelativeDrift  float64       `json:"relativeDrift"`
+}
+
+func NewCaptureStats(timeWindow time.Duration, clock timing.Clock) (s *CaptureStats) {
+	s = new(CaptureStats)
+	s.Interval = timing.NewTiming(timeWindow, clock)
+	s.offsets = timing.NewTimeWindow[time.Duration](timeWindow)
+	return s
+}
+
+func (s *CaptureStats) Clear() {
+	s.Interval.Clear()
+	s.lastCapture = time.Time{}
+	s.offsets.Clear()
+}
+
+// Add adds the capture and receive time of a frame with its classification by frame number and returns log messages
+func (s *CaptureStats) Add(tCapture time.Time, tReceived time.Time, order timing.FrameOrder, numLost int) (events []string) {
+	s.offsets.Prune(tReceived)
+
+	switch order {
+	case timing.FrameDuplicate, timing.FrameReordered:
+		// the capture time is not expected to be after the last frame
+		s.addOffset(tCapture, tReceived)
+		return
+	case timing.FrameReset:
+		s.lastCapture = tCapture
+		s.addOffset(tCapture, tReceived)
+		return
+	}
+	if s.lastCapture.IsZero() {
+		s.lastCapture = tCapture
+		s.addOffset(tCapture, tReceived)
+		return
+	}
+
+	interval := tCapture.Sub(s.lastCapture)
+	if interval <= 0 {
+		// the capture time is wrong, so it is excluded from the drift
+		s.NumNonMonotonic++
+		events = append(events, fmt.Sprintf("capture time decreased by %v", -interval))
+		return
+	}
+	s.lastCapture = tCapture
+	s.addOffset(tCapture, tReceived)
+
+	if s.Interval.NumMeasures() >= minCaptureIntervals {
+		expected := s.Interval.Median
+		missing := int(math.Round(float64(interval)/float64(expected))) - 1 - numLost
+		if missing > 0 {
+			s.NumCaptureGaps++
+			s.NumMissingCaptures += missing
+			events = append(events, fmt.Sprintf("%d frames missing by capture time (interval %v) without a frame number gap", missing, interval))
+		}
+	}
+	s.Interval.Add(interval / time.Duration(numLost+1))
+	return
+}
+
+func (s *CaptureStats) addOffset(tCapture time.Time, tReceived time.Time) {
+	s.offsets.Add(tReceived, tReceived.Sub(tCapture))
+}
+
+// Offset returns the mean time between capturing and receiving a frame
+func (s *CaptureStats) Offset() time.Duration {
+	if s.offsets.Len() == 0 {
+		return 0
+	}
+	var sum time.Duration
+	for _, offset := range s.offsets.All() {
+		sum += offset
+	}
+	return sum / time.Duration(s.offsets.Len())
+}
+
+// Drift returns the slope of the offset over the receive time in microseconds per second by linear regression
+func (s *CaptureStats) Drift() float64 {
+	n := float64(s.offsets.Len())
+	if n < 2 {
+		return 0
+	}
+	tBase := s.offsets.Time(0)
+	offsetBase := s.offsets.At(0)
+	var sumX, sumY, sumXX, sumXY float64
+	for tReceived, offset := range s.offsets.All() {
+		x := tReceived.Sub(tBase).Seconds()
+		y := float64(offset-offsetBase) / float64(time.Microsecond)
+		sumX += x
+		sumY += y
+		sumXX += x * x
+		sumXY += x * y
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+func (s *CaptureStats) String() string {
+	return fmt.Sprintf("interval %v | %v non-monotonic | %v capture gaps (%v missing) | offset %v | drift %.1fppm",
+		s.Interval.Median, s.NumNonMonotonic, s.NumCaptureGaps, s.NumMissingCaptures, s.Offset(), s.Drift())
+}
+
+func (s *CaptureStats) Snapshot() (snapshot CaptureSnapshot) {
+	snapshot.Interval = s.Interval.Snapshot()
+	snapshot.NumNonMonotonic = s.NumNonMonotonic
+	snapshot.NumCaptureGaps = s.NumCaptureGaps
+	snapshot.NumMissingCaptures = s.NumMissingCaptures
+	snapshot.Offset = s.Offset()
+	snapshot.Drift = s.Drift()
+	return
+}
+
+// setRelativeCapture sets the capture offset and drift of each camera relative to the median of all cameras
+func setRelativeCapture(cameras []CamSnapshot) {
+	if len(cameras) < 2 {
+		return
+	}
+	offsets := make([]float64, len(cameras))
+	drifts := make([]float64, len(cameras))
+	for i, cam := range cameras {
+		offsets[i] = float64(cam.Capture.Offset)
+		drifts[i] = cam.Capture.Drift
+	}
+	medianOffset := median(offsets)
+	medianDrift := median(drifts)
+	for i := range cameras {
+		cameras[i].Capture.RelativeOffset = cameras[i].Capture.Offset - time.Duration(medianOffset)
+		cameras[i].Capture.RelativeDrift = cameras[i].Capture.Drift - medianDrift
+	}
+}
+
+func median(values []float64) float64 {
+	sort.Float64s(values)
+	n := len(values)
+	if n%2 == 1 {
+		return values[n/2]
+	}
+	return (values[n/2-1] + values[n/2]) / 2
+}
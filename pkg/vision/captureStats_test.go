@@ -0,0 +1,70 @@
+package vision
+
+import (
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCaptureStats_Add(t *testing.T) {
+	clock := timing.NewManualClock(time.Unix(1000, 0))
+	stats := NewCaptureStats(10*time.Second, clock)
+	interval := 10 * time.Millisecond
+
+	add := func(order timing.FrameOrder, numLost int) []string {
+		// the capture clock runs 100µs per second slower than the receiving host
+		tReceived := clock.Now()
+		offset := 5*time.Millisecond + time.Duration(tReceived.Sub(time.Unix(1000, 0)).Seconds()*100e3)
+		return stats.Add(tReceived.Add(-offset), tReceived, order, numLost)
+	}
+	for i := 0; i < 20; i++ {
+		if events := add(timing.FrameInOrder, 0); len(events) > 0 {
+			t.Errorf("Unexpected events: %v", events)
+		}
+		clock.Add(interval)
+	}
+
+	// two frames lost in the network
+	clock.Add(2 * interval)
+	if events := add(timing.FrameGap, 2); len(events) > 0 {
+		t.Errorf("Unexpected events for a frame number gap: %v", events)
+	}
+	clock.Add(interval)
+
+	// two frames not captured, but the frame number is continuous
+	clock.Add(2 * interval)
+	if events := add(timing.FrameInOrder, 0); len(events) != 1 {
+		t.Errorf("Expected one event for a capture gap, got %v", events)
+	}
+	if stats.NumCaptureGaps != 1 || stats.NumMissingCaptures != 2 {
+		t.Errorf("Expected 1 capture gap with 2 missing frames, got %v with %v", stats.NumCaptureGaps, stats.NumMissingCaptures)
+	}
+
+	if events := stats.Add(time.Unix(999, 0), clock.Now(), timing.FrameInOrder, 0); len(events) != 1 || stats.NumNonMonotonic != 1 {
+		t.Errorf("Expected a non-monotonic capture time, got %v", events)
+	}
+
+	if drift := stats.Drift(); math.Abs(drift-100) > 1 {
+		t.Errorf("Expected a drift of 100ppm, got %v", drift)
+	}
+	if median := stats.Interval.Median; median < interval-time.Microsecond || median > interval+time.Microsecond {
+		t.Errorf("Expected a capture interval of %v, got %v", interval, median)
+	}
+}
+
+func TestSetRelativeCapture(t *testing.T) {
+	cameras := []CamSnapshot{
+		{Capture: CaptureSnapshot{Offset: 5 * time.Millisecond, Drift: 1}},
+		{Capture: CaptureSnapshot{Offset: 6 * time.Millisecond, Drift: 2}},
+		{Capture: CaptureSnapshot{Offset: 9 * time.Millisecond, Drift: 50}},
+	}
+	setRelativeCapture(cameras)
+	if cameras[2].Capture.RelativeOffset != 3*time.Millisecond || cameras[2].Capture.RelativeDrift != 48 {
+		t.Errorf("Unexpected relative offset %v and drift %v", cameras[2].Capture.RelativeOffset, cameras[2].Capture.RelativeDrift)
+	}
+	if cameras[1].Capture.RelativeOffset != 0 || cameras[1].Capture.RelativeDrift != 0 {
+		t.Errorf("Expected no relative offset and drift for the median camera, got %v and %v",
+			cameras[1].Capture.RelativeOffset, cameras[1].Capture.RelativeDrift)
+	}
+}
@@ -1,10 +1,51 @@
 package vision
 
-import "time"
+import (
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"time"
+)
+
+// defaultVisibleRobotQuality is the minimum detection quality of a visible robot if it is not configured
+const defaultVisibleRobotQuality = 0.5
 
 type StatsConfig struct {
+	// Clock is the time source for receiving times and rates, the wall clock is used if unset
+	Clock                  timing.Clock
 	TimeWindowVisibility   time.Duration
 	TimeWindowQualityCam   time.Duration
 	TimeWindowQualityBall  time.Duration
 	TimeWindowQualityRobot time.Duration
+	// TimeWindowReprojection is the time window for the reprojection error of detections
+	TimeWindowReprojection time.Duration
+	// TimeWindowCapture is the time window for the capture interval and the drift of the capture clock
+	TimeWindowCapture time.Duration
+	// TimeWindowCrossCam is the time window for comparing detections of different cameras
+	TimeWindowCrossCam time.Duration
+	// MaxCrossCamTimeDiff is the maximum difference between capture times of detections of different cameras to be compared
+	MaxCrossCamTimeDiff time.Duration
+	// MaxDuplicateTimeDiff is the maximum difference between capture times of frames of different cameras
+	// to be checked for duplicate robot ids
+	MaxDuplicateTimeDiff time.Duration
+	// VisibleRobotQuality is the minimum detection quality of a robot to be counted as visible,
+	// defaultVisibleRobotQuality is used if unset
+	VisibleRobotQuality float64
+	// QualityThresholds define the colors of quality values, timing.DefaultQualityThresholds is used if unset
+	QualityThresholds timing.QualityThresholds
+	// Distribution defines the percentiles and histogram buckets of the frame timings, timing.DefaultDistribution is used if unset
+	Distribution timing.Distribution
+	// CoverageCellSize is the size of a cell of the field coverage grid in meters
+	CoverageCellSize float64
+	// TimeWindowBlob is the time window for the confidence, blob area and height of ball detections
+	TimeWindowBlob time.Duration
+	// TimeWindowRobotIds is the time window for the id stability of robots
+	TimeWindowRobotIds time.Duration
+	// TimeWindowStationary is the time window for measuring the position noise of stationary objects
+	TimeWindowStationary time.Duration
+	// StationaryRadius is the maximum deviation from the mean position in meters for an object to be detected as stationary
+	StationaryRadius float64
+	// AssumeStationary treats all objects as stationary, for example while measuring the noise during the field setup
+	AssumeStationary bool
+	// OnlyDuringPlay restricts the statistics of balls, robots, camera overlap and coverage to running play,
+	// frame timing statistics are always collected
+	OnlyDuringPlay bool
 }
@@ -0,0 +1,155 @@
+package vision
+
+import (
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/heatmap"
+	"math"
+)
+
+// CoverageStats accumulates the detections and their quality on a grid over the whole field
+type CoverageStats struct {
+	cellSize float64
+	field    *SSL_GeometryFieldSize
+	Field    *CoverageGrid
+	Cameras  map[int]*CoverageGrid
+}
+
+// CoverageGrid is a grid over the field including the boundary, the first row has the smallest y coordinate
+type CoverageGrid struct {
+	MinX     float64          `json:"minX"`
+	MinY     float64          `json:"minY"`
+	CellSize float64          `json:"cellSize"`
+	Cells    [][]CoverageCell `json:"cells"`
+}
+
+type CoverageCell struct {
+	NumDetections int     `json:"numDetections"`
+	QualitySum    float64 `json:"qualitySum"`
+}
+
+func NewCoverageStats(cellSize float64) (s *CoverageStats) {
+	s = new(CoverageStats)
+	s.cellSize = cellSize
+	s.Cameras = map[int]*CoverageGrid{}
+	return s
+}
+
+// SetField sets the field size and resets all grids if the size changed
+func (s *CoverageStats) SetField(field *SSL_GeometryFieldSize) {
+	if s.field != nil &&
+		s.field.GetFieldLength() == field.GetFieldLength() &&
+		s.field.GetFieldWidth() == field.GetFieldWidth() &&
+		s.field.GetBoundaryWidth() == field.GetBoundaryWidth() {
+		return
+	}
+	s.field = field
+	s.Reset()
+}
+
+// Reset clears all grids
+func (s *CoverageStats) Reset() {
+	s.Cameras = map[int]*CoverageGrid{}
+	s.Field = s.newGrid()
+}
+
+func (s *CoverageStats) newGrid() *CoverageGrid {
+	if s.field == nil || s.cellSize <= 0 {
+		return nil
+	}
+	halfLength := float64(s.field.GetFieldLength())/2000 + float64(s.field.GetBoundaryWidth())/1000
+	halfWidth := float64(s.field.GetFieldWidth())/2000 + float64(s.field.GetBoundaryWidth())/1000
+	cols := int(math.Ceil(2 * halfLength / s.cellSize))
+	rows := int(math.Ceil(2 * halfWidth / s.cellSize))
+	grid := new(CoverageGrid)
+	grid.MinX = -halfLength
+	grid.MinY = -halfWidth
+	grid.CellSize = s.cellSize
+	grid.Cells = make([][]CoverageCell, rows)
+	for row := range grid.Cells {
+		grid.Cells[row] = make([]CoverageCell, cols)
+	}
+	return grid
+}
+
+// Add adds a detection at the given position in meters with the current detection quality of the object
+func (s *CoverageStats) Add(camId int, pos Position2d, quality float64) {
+	if s.Field == nil {
+		// field size is not known yet
+		return
+	}
+	camGrid, ok := s.Cameras[camId]
+	if !ok {
+		camGrid = s.newGrid()
+		s.Cameras[camId] = camGrid
+	}
+	s.Field.Add(pos, quality)
+	camGrid.Add(pos, quality)
+}
+
+func (g *CoverageGrid) Add(pos Position2d, quality float64) {
+	row := int(math.Floor((float64(pos.Y) - g.MinY) / g.CellSize))
+	col := int(math.Floor((float64(pos.X) - g.MinX) / g.CellSize))
+	if row < 0 || row >= len(g.Cells) || col < 0 || col >= len(g.Cells[row]) {
+		return
+	}
+	g.Cells[row][col].NumDetections++
+	g.Cells[row][col].QualitySum += quality
+}
+
+// Heatmap converts the grid to a heat map of the mean detection quality with the given field lines
+func (g *CoverageGrid) Heatmap(field *SSL_GeometryFieldSize) (m heatmap.Map) {
+	m.MinX = g.MinX
+	m.MinY = g.MinY
+	m.CellSize = g.CellSize
+	m.Cells = make([][]heatmap.Cell, len(g.Cells))
+	for row := range g.Cells {
+		m.Cells[row] = make([]heatmap.Cell, len(g.Cells[row]))
+		for col, cell := range g.Cells[row] {
+			m.Cells[row][col].Count = cell.NumDetections
+			if cell.NumDetections > 0 {
+				m.Cells[row][col].Value = cell.QualitySum / float64(cell.NumDetections)
+			}
+		}
+	}
+	if field != nil {
+		for _, line := range field.FieldLines {
+			m.Lines = append(m.Lines, heatmap.Line{
+				X1: float64(line.GetP1().GetX()) / 1000,
+				Y1: float64(line.GetP1().GetY()) / 1000,
+				X2: float64(line.GetP2().GetX()) / 1000,
+				Y2: float64(line.GetP2().GetY()) / 1000,
+			})
+		}
+	}
+	return
+}
+
+// Grid returns the grid of the whole field for camId < 0, else of the given camera. It returns nil if there is no grid.
+func (s *CoverageStats) Grid(camId int) *CoverageGrid {
+	if camId < 0 {
+		return s.Field
+	}
+	return s.Cameras[camId]
+}
+
+// Heatmap returns the heat map for the whole field for camId < 0, else for the given camera
+func (s *CoverageStats) Heatmap(camId int) (m heatmap.Map, ok bool) {
+	grid := s.Grid(camId)
+	if grid == nil {
+		return m, false
+	}
+	return grid.Heatmap(s.field), true
+}
+
+// CoverageHeatmap returns a copy of the coverage heat map for the whole field for camId < 0, else for the given camera
+func (s *Stats) CoverageHeatmap(camId int) (heatmap.Map, bool) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	return s.Coverage.Heatmap(camId)
+}
+
+// ResetCoverage clears the coverage of the whole field and all cameras
+func (s *Stats) ResetCoverage() {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	s.Coverage.Reset()
+}
@@ -0,0 +1,78 @@
+package vision
+
+import (
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+func testField(fieldLength int32, fieldWidth int32, boundaryWidth int32) *SSL_GeometryFieldSize {
+	return &SSL_GeometryFieldSize{
+		FieldLength:   proto.Int32(fieldLength),
+		FieldWidth:    proto.Int32(fieldWidth),
+		BoundaryWidth: proto.Int32(boundaryWidth),
+	}
+}
+
+func TestCoverageStats_Add(t *testing.T) {
+	stats := NewCoverageStats(0.5)
+	stats.Add(0, Position2d{X: 0, Y: 0}, 1)
+	if stats.Field != nil {
+		t.Fatal("Expected no grid without a field size")
+	}
+
+	// 13m x 10m including the boundary
+	stats.SetField(testField(12000, 9000, 500))
+	if rows, cols := len(stats.Field.Cells), len(stats.Field.Cells[0]); rows != 20 || cols != 26 {
+		t.Fatalf("Expected 20x26 cells, got %vx%v", rows, cols)
+	}
+
+	stats.Add(0, Position2d{X: -6.5, Y: -5}, 1)
+	// on the boundary between the first and the second cell
+	stats.Add(0, Position2d{X: -6, Y: -5}, 0.5)
+	stats.Add(1, Position2d{X: 6.4, Y: 4.9}, 0.8)
+	// outside of the field including the boundary
+	stats.Add(1, Position2d{X: 6.5, Y: 0}, 1)
+	stats.Add(1, Position2d{X: 0, Y: -5.1}, 1)
+
+	field := stats.Grid(-1)
+	if cell := field.Cells[0][0]; cell.NumDetections != 1 || cell.QualitySum != 1 {
+		t.Errorf("Expected a detection in the first cell, got %+v", cell)
+	}
+	if cell := field.Cells[0][1]; cell.NumDetections != 1 || cell.QualitySum != 0.5 {
+		t.Errorf("Expected a detection in the second cell, got %+v", cell)
+	}
+	if cell := field.Cells[19][25]; cell.NumDetections != 1 {
+		t.Errorf("Expected a detection in the last cell, got %+v", cell)
+	}
+	numDetections := func(grid *CoverageGrid) (n int) {
+		for _, row := range grid.Cells {
+			for _, cell := range row {
+				n += cell.NumDetections
+			}
+		}
+		return
+	}
+	if n := numDetections(field); n != 3 {
+		t.Errorf("Expected 3 detections on the field, got %v", n)
+	}
+	if n0, n1 := numDetections(stats.Grid(0)), numDetections(stats.Grid(1)); n0 != 2 || n1 != 1 {
+		t.Errorf("Expected 2 detections of camera 0 and 1 of camera 1, got %v and %v", n0, n1)
+	}
+
+	m, ok := stats.Heatmap(0)
+	if !ok || m.Cells[0][1].Count != 1 || m.Cells[0][1].Value != 0.5 {
+		t.Errorf("Expected the mean quality in the heat map, got %+v", m.Cells[0][1])
+	}
+	if _, ok := stats.Heatmap(5); ok {
+		t.Error("Expected no heat map for an unknown camera")
+	}
+
+	stats.SetField(testField(12000, 9000, 500))
+	if numDetections(stats.Field) != 3 {
+		t.Error("Expected the grids to be kept for the same field size")
+	}
+	stats.SetField(testField(9000, 6000, 300))
+	if numDetections(stats.Field) != 0 || len(stats.Cameras) != 0 {
+		t.Error("Expected the grids to be reset for a new field size")
+	}
+}
@@ -0,0 +1,270 @@
+package vision
+
+import (
+	"fmt"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"math"
+	"sort"
+	"time"
+)
+
+// maxBallMatchDistance is the maximum distance in meters of two ball detections from different cameras to be considered the same ball
+const maxBallMatchDistance = 0.5
+
+// CrossCamStats compares detections of the same objects from different cameras
+type CrossCamStats struct {
+	// maxTimeDiff is the maximum difference of the capture times of two detections to be compared
+	maxTimeDiff time.Duration
+	timeWindow  time.Duration
+	robots      map[RobotId]map[int]crossCamDetection
+	balls       map[int]crossCamBalls
+	Pairs       map[CamPair]*CamPairStats
+}
+
+// CamPair identifies two cameras, CamA is always smaller than CamB
+type CamPair struct {
+	CamA int
+	CamB int
+}
+
+// CamPairStats contains the offsets of detections of CamB relative to CamA
+type CamPairStats struct {
+	Robots *timing.TimeWindow[crossCamOffset]
+	Balls  *timing.TimeWindow[crossCamOffset]
+}
+
+type crossCamDetection struct {
+	tCapture    time.Time
+	pos         Position2d
+	orientation *float32
+}
+
+type crossCamBalls struct {
+	tCapture time.Time
+	pos      []Position2d
+}
+
+type crossCamOffset struct {
+	dx           float64
+	dy           float64
+	dOrientation *float64
+}
+
+type CamPairSnapshot struct {
+	CamA   int            `json:"camA"`
+	CamB   int            `json:"camB"`
+	Robots OffsetSnapshot `json:"robots"`
+	Balls  OffsetSnapshot `json:"balls"`
+}
+
+// OffsetSnapshot summarizes the offsets of CamB relative to CamA, distances are in meters and angles in radian
+type OffsetSnapshot struct {
+	NumSamples            int     `json:"numSamples"`
+	MeanDx                float64 `json:"meanDx"`
+	MeanDy                float64 `json:"meanDy"`
+	MeanDistance          float64 `json:"meanDistance"`
+	MaxDistance           float64 `json:"maxDistance"`
+	NumOrientationSamples int     `json:"numOrientationSamples"`
+	MeanOrientation       float64 `json:"meanOrientation"`
+	MaxOrientation        float64 `json:"maxOrientation"`
+	OrientationStdDev     float64 `json:"orientationStdDev"`
+}
+
+func NewCrossCamStats(maxTimeDiff time.Duration, timeWindow time.Duration) (s *CrossCamStats) {
+	s = new(CrossCamStats)
+	s.maxTimeDiff = maxTimeDiff
+	s.timeWindow = timeWindow
+	s.robots = map[RobotId]map[int]crossCamDetection{}
+	s.balls = map[int]crossCamBalls{}
+	s.Pairs = map[CamPair]*CamPairStats{}
+	return s
+}
+
+func (s *CrossCamStats) pairStats(camA, camB int) (stats *CamPairStats, sign float64) {
+	sign = 1
+	if camA > camB {
+		camA, camB = camB, camA
+		sign = -1
+	}
+	pair := CamPair{CamA: camA, CamB: camB}
+	stats, ok := s.Pairs[pair]
+	if !ok {
+		stats = new(CamPairStats)
+		stats.Robots = timing.NewTimeWindow[crossCamOffset](s.timeWindow)
+		stats.Balls = timing.NewTimeWindow[crossCamOffset](s.timeWindow)
+		s.Pairs[pair] = stats
+	}
+	return
+}
+
+func (s *CrossCamStats) isConcurrent(t1, t2 time.Time) bool {
+	dt := t1.Sub(t2)
+	return dt <= s.maxTimeDiff && dt >= -s.maxTimeDiff
+}
+
+// AddRobot compares the detection with the latest detection of the same robot on all other cameras
+func (s *CrossCamStats) AddRobot(camId int, robotId RobotId, tCapture time.Time, pos Position2d, orientation *float32) {
+	detections, ok := s.robots[robotId]
+	if !ok {
+		detections = map[int]crossCamDetection{}
+		s.robots[robotId] = detections
+	}
+	for otherCamId, other := range detections {
+		if otherCamId == camId || !s.isConcurrent(tCapture, other.tCapture) {
+			continue
+		}
+		if pos.DistanceTo(other.pos) > minDuplicateDistance {
+			// different robots with the same id, see DuplicateStats
+			continue
+		}
+		pairStats, sign := s.pairStats(otherCamId, camId)
+		offset := crossCamOffset{
+			dx: sign * float64(pos.X-other.pos.X),
+			dy: sign * float64(pos.Y-other.pos.Y),
+		}
+		if orientation != nil && other.orientation != nil {
+			dOrientation := sign * normalizeAngle(float64(*orientation-*other.orientation))
+			offset.dOrientation = &dOrientation
+		}
+		pairStats.Robots.Add(tCapture, offset)
+	}
+	detections[camId] = crossCamDetection{tCapture: tCapture, pos: pos, orientation: orientation}
+}
+
+// AddBalls compares all balls of a frame with the closest ball of the latest frame of all other cameras
+func (s *CrossCamStats) AddBalls(camId int, tCapture time.Time, balls []Position2d) {
+	for otherCamId, other := range s.balls {
+		if otherCamId == camId || !s.isConcurrent(tCapture, other.tCapture) {
+			continue
+		}
+		pairStats, sign := s.pairStats(otherCamId, camId)
+		for _, ball := range balls {
+			minDistance := maxBallMatchDistance
+			var closest *Position2d
+			for i := range other.pos {
+				if distance := ball.DistanceTo(other.pos[i]); distance < minDistance {
+					minDistance = distance
+					closest = &other.pos[i]
+				}
+			}
+			if closest != nil {
+				pairStats.Balls.Add(tCapture, crossCamOffset{
+					dx: sign * float64(ball.X-closest.X),
+					dy: sign * float64(ball.Y-closest.Y),
+				})
+			}
+		}
+	}
+	s.balls[camId] = crossCamBalls{tCapture: tCapture, pos: balls}
+}
+
+// Prune removes all offsets that are older than the time window
+func (s *CrossCamStats) Prune(tLatest time.Time) {
+	tOldest := tLatest.Add(-s.timeWindow)
+	for pair, stats := range s.Pairs {
+		stats.Robots.Prune(tLatest)
+		stats.Balls.Prune(tLatest)
+		if stats.Robots.Len() == 0 && stats.Balls.Len() == 0 {
+			delete(s.Pairs, pair)
+		}
+	}
+	for robotId, detections := range s.robots {
+		for camId, detection := range detections {
+			if detection.tCapture.Before(tOldest) {
+				delete(detections, camId)
+			}
+		}
+		if len(detections) == 0 {
+			delete(s.robots, robotId)
+		}
+	}
+}
+
+func (s *CrossCamStats) SortedPairs() []CamPair {
+	pairs := make([]CamPair, 0, len(s.Pairs))
+	for pair := range s.Pairs {
+		pairs = append(pairs, pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].CamA != pairs[j].CamA {
+			return pairs[i].CamA < pairs[j].CamA
+		}
+		return pairs[i].CamB < pairs[j].CamB
+	})
+	return pairs
+}
+
+func (s *CrossCamStats) Snapshot() []CamPairSnapshot {
+	snapshots := []CamPairSnapshot{}
+	for _, pair := range s.SortedPairs() {
+		stats := s.Pairs[pair]
+		snapshots = append(snapshots, CamPairSnapshot{
+			CamA:   pair.CamA,
+			CamB:   pair.CamB,
+			Robots: newOffsetSnapshot(stats.Robots),
+			Balls:  newOffsetSnapshot(stats.Balls),
+		})
+	}
+	return snapshots
+}
+
+func newOffsetSnapshot(offsets *timing.TimeWindow[crossCamOffset]) (s OffsetSnapshot) {
+	var sumOrientation, sqSumOrientation float64
+	for _, offset := range offsets.All() {
+		distance := math.Sqrt(offset.dx*offset.dx + offset.dy*offset.dy)
+		s.NumSamples++
+		s.MeanDx += offset.dx
+		s.MeanDy += offset.dy
+		s.MeanDistance += distance
+		s.MaxDistance = math.Max(s.MaxDistance, distance)
+		if offset.dOrientation != nil {
+			s.NumOrientationSamples++
+			sumOrientation += *offset.dOrientation
+			sqSumOrientation += *offset.dOrientation * *offset.dOrientation
+			s.MaxOrientation = math.Max(s.MaxOrientation, math.Abs(*offset.dOrientation))
+		}
+	}
+	if s.NumSamples > 0 {
+		n := float64(s.NumSamples)
+		s.MeanDx /= n
+		s.MeanDy /= n
+		s.MeanDistance /= n
+	}
+	if s.NumOrientationSamples > 0 {
+		n := float64(s.NumOrientationSamples)
+		s.MeanOrientation = sumOrientation / n
+		s.OrientationStdDev = math.Sqrt(math.Max(0, sqSumOrientation/n-s.MeanOrientation*s.MeanOrientation))
+	}
+	return
+}
+
+func (s OffsetSnapshot) String() string {
+	if s.NumSamples == 0 {
+		return "no samples"
+	}
+	str := fmt.Sprintf("Δ %5.0fmm/%5.0fmm | dist %4.0fmm max %4.0fmm",
+		s.MeanDx*1000, s.MeanDy*1000, s.MeanDistance*1000, s.MaxDistance*1000)
+	if s.NumOrientationSamples > 0 {
+		str += fmt.Sprintf(" | Δθ %5.1f° σ %4.1f° max %5.1f°",
+			s.MeanOrientation*180/math.Pi, s.OrientationStdDev*180/math.Pi, s.MaxOrientation*180/math.Pi)
+	}
+	return str + fmt.Sprintf(" (%v samples)", s.NumSamples)
+}
+
+func (s *CrossCamStats) String() string {
+	str := ""
+	for _, snapshot := range s.Snapshot() {
+		str += fmt.Sprintf("Camera %d ↔ %d robots: %v\n", snapshot.CamA, snapshot.CamB, snapshot.Robots)
+		str += fmt.Sprintf("Camera %d ↔ %d  balls: %v\n", snapshot.CamA, snapshot.CamB, snapshot.Balls)
+	}
+	return str
+}
+
+// normalizeAngle normalizes an angle to [-π, π)
+func normalizeAngle(angle float64) float64 {
+	angle = math.Mod(angle+math.Pi, 2*math.Pi)
+	if angle < 0 {
+		angle += 2 * math.Pi
+	}
+	return angle - math.Pi
+}
@@ -0,0 +1,83 @@
+package vision
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCrossCamStats_Offsets(t *testing.T) {
+	robotId := NewRobotId(3, TeamBlue)
+	tStart := time.Unix(1000, 0)
+	posA := Position2d{X: 1, Y: 2}
+	// camera 2 sees the objects 30mm further in x and 20mm less in y than camera 0
+	posB := Position2d{X: 1.03, Y: 1.98}
+	var orientationA float32 = 0.1
+	var orientationB float32 = 0.15
+
+	tests := []struct {
+		name  string
+		first int
+		// dt is the capture time of the second camera relative to the first
+		dt                   time.Duration
+		numSamples           int
+		expectedDx           float64
+		expectedDy           float64
+		expectedDOrientation float64
+	}{
+		{name: "lower camera first", first: 0, dt: 5 * time.Millisecond, numSamples: 1, expectedDx: 0.03, expectedDy: -0.02, expectedDOrientation: 0.05},
+		{name: "higher camera first", first: 2, dt: 5 * time.Millisecond, numSamples: 1, expectedDx: 0.03, expectedDy: -0.02, expectedDOrientation: 0.05},
+		{name: "not concurrent", first: 0, dt: 50 * time.Millisecond, numSamples: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stats := NewCrossCamStats(10*time.Millisecond, time.Second)
+			add := func(camId int, tCapture time.Time) {
+				pos, orientation := posA, orientationA
+				if camId == 2 {
+					pos, orientation = posB, orientationB
+				}
+				stats.AddRobot(camId, robotId, tCapture, pos, &orientation)
+				stats.AddBalls(camId, tCapture, []Position2d{pos})
+			}
+			second := 2 - test.first
+			add(test.first, tStart)
+			add(second, tStart.Add(test.dt))
+
+			snapshots := stats.Snapshot()
+			if test.numSamples == 0 {
+				if len(snapshots) != 0 {
+					t.Errorf("Expected no camera pairs, got %+v", snapshots)
+				}
+				return
+			}
+			if len(snapshots) != 1 || snapshots[0].CamA != 0 || snapshots[0].CamB != 2 {
+				t.Fatalf("Expected the camera pair 0 ↔ 2, got %+v", snapshots)
+			}
+			for _, offsets := range []OffsetSnapshot{snapshots[0].Robots, snapshots[0].Balls} {
+				if offsets.NumSamples != test.numSamples ||
+					math.Abs(offsets.MeanDx-test.expectedDx) > 1e-6 || math.Abs(offsets.MeanDy-test.expectedDy) > 1e-6 {
+					t.Errorf("Expected offset %v/%v, got %+v", test.expectedDx, test.expectedDy, offsets)
+				}
+			}
+			if robots := snapshots[0].Robots; robots.NumOrientationSamples != 1 || math.Abs(robots.MeanOrientation-test.expectedDOrientation) > 1e-6 {
+				t.Errorf("Expected orientation offset %v, got %+v", test.expectedDOrientation, robots)
+			}
+		})
+	}
+}
+
+func TestCrossCamStats_Prune(t *testing.T) {
+	stats := NewCrossCamStats(10*time.Millisecond, time.Second)
+	tStart := time.Unix(1000, 0)
+	stats.AddBalls(0, tStart, []Position2d{{X: 0, Y: 0}})
+	stats.AddBalls(1, tStart, []Position2d{{X: 0.01, Y: 0}, {X: 3, Y: 0}})
+	if snapshots := stats.Snapshot(); len(snapshots) != 1 || snapshots[0].Balls.NumSamples != 1 {
+		t.Fatalf("Expected a single matched ball, got %+v", snapshots)
+	}
+
+	stats.Prune(tStart.Add(2 * time.Second))
+	if snapshots := stats.Snapshot(); len(snapshots) != 0 {
+		t.Errorf("Expected all offsets to be pruned, got %+v", snapshots)
+	}
+}
@@ -0,0 +1,221 @@
+package vision
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// minDuplicateDistance is the minimum distance in meters of two detections of the same robot id to be considered different robots
+const minDuplicateDistance = 0.5
+
+// duplicateTimeout is the time without a duplicate detection after which a duplicate robot id has ended
+const duplicateTimeout = time.Second
+
+// maxDuplicateHistory is the number of ended duplicates that are kept
+const maxDuplicateHistory = 20
+
+// DuplicateStats detects robot ids that are detected at different places at the same time,
+// either within a single frame or by two cameras
+type DuplicateStats struct {
+	// Active are the currently detected duplicates
+	Active map[RobotId]*Duplicate
+	// History contains the most recently ended duplicates
+	History []Duplicate
+	// NumDuplicates counts the duplicates per camera id
+	NumDuplicates map[int]int
+	// maxTimeDiff is the maximum difference of the capture times of two cameras to be compared
+	maxTimeDiff time.Duration
+	latest      map[RobotId]map[int]duplicateFrame
+}
+
+// Duplicate is a robot id that was detected at different places at the same time
+type Duplicate struct {
+	Id       RobotId
+	Start    time.Time
+	LastSeen time.Time
+	// Cameras are all cameras that detected the duplicate
+	Cameras map[int]bool
+	// Detections are the latest positions of the robot id that are far apart
+	Detections []DuplicateDetection
+}
+
+type duplicateFrame struct {
+	tCapture  time.Time
+	positions []Position2d
+}
+
+type DuplicateDetection struct {
+	CameraId int        `json:"cameraId"`
+	Position Position2d `json:"position"`
+}
+
+type DuplicateSnapshot struct {
+	Id         int                  `json:"id"`
+	Color      TeamColor            `json:"color"`
+	CameraIds  []int                `json:"cameraIds"`
+	Detections []DuplicateDetection `json:"detections"`
+	Start      time.Time            `json:"start"`
+	Duration   time.Duration        `json:"duration"`
+	Active     bool                 `json:"active"`
+}
+
+func NewDuplicateStats(maxTimeDiff time.Duration) (s *DuplicateStats) {
+	s = new(DuplicateStats)
+	s.Active = map[RobotId]*Duplicate{}
+	s.NumDuplicates = map[int]int{}
+	s.maxTimeDiff = maxTimeDiff
+	s.latest = map[RobotId]map[int]duplicateFrame{}
+	return s
+}
+
+// AddFrame compares the robot positions of a frame with each other and with the latest frame of all other cameras
+// and returns log messages about new duplicates
+func (s *DuplicateStats) AddFrame(camId int, tCapture time.Time, robots map[RobotId][]Position2d) (events []string) {
+	for _, robotId := range sortedRobotIds(robots) {
+		positions := robots[robotId]
+		frames, ok := s.latest[robotId]
+		if !ok {
+			frames = map[int]duplicateFrame{}
+			s.latest[robotId] = frames
+		}
+		frames[camId] = duplicateFrame{tCapture: tCapture, positions: positions}
+
+		if detections := s.farApart(robotId, tCapture); len(detections) > 0 {
+			if event := s.addDuplicate(robotId, tCapture, detections); event != "" {
+				events = append(events, event)
+			}
+		}
+	}
+	return
+}
+
+// farApart returns the concurrent detections of the robot id, if at least two of them are far apart
+func (s *DuplicateStats) farApart(robotId RobotId, tCapture time.Time) []DuplicateDetection {
+	var detections []DuplicateDetection
+	for _, camId := range sortedKeys(s.latest[robotId]) {
+		frame := s.latest[robotId][camId]
+		dt := tCapture.Sub(frame.tCapture)
+		if dt > s.maxTimeDiff || dt < -s.maxTimeDiff {
+			continue
+		}
+		for _, pos := range frame.positions {
+			detections = append(detections, DuplicateDetection{CameraId: camId, Position: pos})
+		}
+	}
+	for i := range detections {
+		for j := i + 1; j < len(detections); j++ {
+			if detections[i].Position.DistanceTo(detections[j].Position) > minDuplicateDistance {
+				return detections
+			}
+		}
+	}
+	return nil
+}
+
+func (s *DuplicateStats) addDuplicate(robotId RobotId, t time.Time, detections []DuplicateDetection) (event string) {
+	duplicate, ok := s.Active[robotId]
+	if !ok {
+		duplicate = &Duplicate{Id: robotId, Start: t, Cameras: map[int]bool{}}
+		s.Active[robotId] = duplicate
+		event = fmt.Sprintf("Robot %v%d detected at multiple places: %v", robotId.Color, robotId.Id, formatDetections(detections))
+	}
+	duplicate.LastSeen = t
+	duplicate.Detections = detections
+	for _, detection := range detections {
+		if !duplicate.Cameras[detection.CameraId] {
+			duplicate.Cameras[detection.CameraId] = true
+			s.NumDuplicates[detection.CameraId]++
+		}
+	}
+	return
+}
+
+// Prune ends all duplicates that were not detected within duplicateTimeout and returns log messages about them
+func (s *DuplicateStats) Prune(t time.Time) (events []string) {
+	for _, robotId := range sortedRobotIds(s.Active) {
+		duplicate := s.Active[robotId]
+		if t.Sub(duplicate.LastSeen) <= duplicateTimeout {
+			continue
+		}
+		delete(s.Active, robotId)
+		s.History = append(s.History, *duplicate)
+		if len(s.History) > maxDuplicateHistory {
+			s.History = s.History[1:]
+		}
+		events = append(events, fmt.Sprintf("Robot %v%d is no longer detected at multiple places after %v",
+			robotId.Color, robotId.Id, duplicate.LastSeen.Sub(duplicate.Start).Truncate(time.Millisecond)))
+	}
+	for robotId, frames := range s.latest {
+		for camId, frame := range frames {
+			if t.Sub(frame.tCapture) > duplicateTimeout {
+				delete(frames, camId)
+			}
+		}
+		if len(frames) == 0 {
+			delete(s.latest, robotId)
+		}
+	}
+	return
+}
+
+// NumActive returns the number of active duplicates that were detected by the camera
+func (s *DuplicateStats) NumActive(camId int) (n int) {
+	for _, duplicate := range s.Active {
+		if duplicate.Cameras[camId] {
+			n++
+		}
+	}
+	return
+}
+
+// Snapshot returns the active duplicates followed by the history, most recent first
+func (s *DuplicateStats) Snapshot() (snapshot []DuplicateSnapshot) {
+	snapshot = []DuplicateSnapshot{}
+	for _, robotId := range sortedRobotIds(s.Active) {
+		snapshot = append(snapshot, s.Active[robotId].Snapshot(true))
+	}
+	for i := len(s.History) - 1; i >= 0; i-- {
+		snapshot = append(snapshot, s.History[i].Snapshot(false))
+	}
+	return
+}
+
+func (d *Duplicate) Snapshot(active bool) DuplicateSnapshot {
+	return DuplicateSnapshot{
+		Id:         d.Id.Id,
+		Color:      d.Id.Color,
+		CameraIds:  sortedKeys(d.Cameras),
+		Detections: d.Detections,
+		Start:      d.Start,
+		Duration:   d.LastSeen.Sub(d.Start),
+		Active:     active,
+	}
+}
+
+func (s DuplicateSnapshot) String() string {
+	return fmt.Sprintf("robot %v%d at %v for %v", s.Color, s.Id, formatDetections(s.Detections), s.Duration.Truncate(time.Millisecond))
+}
+
+func formatDetections(detections []DuplicateDetection) string {
+	var str []string
+	for _, detection := range detections {
+		str = append(str, fmt.Sprintf("(%.2f, %.2f) by camera %d", detection.Position.X, detection.Position.Y, detection.CameraId))
+	}
+	return strings.Join(str, ", ")
+}
+
+func sortedRobotIds[V any](m map[RobotId]V) []RobotId {
+	ids := make([]RobotId, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if ids[i].Color != ids[j].Color {
+			return ids[i].Color < ids[j].Color
+		}
+		return ids[i].Id < ids[j].Id
+	})
+	return ids
+}
@@ -0,0 +1,51 @@
+package vision
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDuplicateStats_AddFrame(t *testing.T) {
+	stats := NewDuplicateStats(10 * time.Millisecond)
+	tStart := time.Unix(1000, 0)
+	robot3 := NewRobotId(3, TeamBlue)
+
+	// the overlap of two cameras is no duplicate
+	stats.AddFrame(0, tStart, map[RobotId][]Position2d{robot3: {{X: 0, Y: 0}}})
+	if events := stats.AddFrame(1, tStart.Add(time.Millisecond), map[RobotId][]Position2d{robot3: {{X: 0.02, Y: 0}}}); len(events) != 0 {
+		t.Errorf("Unexpected duplicate in the overlap of two cameras: %v", events)
+	}
+
+	// a second robot with the same id in another camera
+	tNext := tStart.Add(20 * time.Millisecond)
+	stats.AddFrame(0, tNext, map[RobotId][]Position2d{robot3: {{X: 0, Y: 0}}})
+	if events := stats.AddFrame(1, tNext, map[RobotId][]Position2d{robot3: {{X: 3, Y: 0}}}); len(events) != 1 {
+		t.Errorf("Expected a duplicate across cameras, got %v", events)
+	}
+	if stats.NumDuplicates[0] != 1 || stats.NumDuplicates[1] != 1 || stats.NumActive(0) != 1 {
+		t.Errorf("Expected a duplicate for both cameras, got %v", stats.NumDuplicates)
+	}
+
+	// both robots in a single frame of the same camera do not start a new duplicate
+	tNext = tStart.Add(500 * time.Millisecond)
+	if events := stats.AddFrame(1, tNext, map[RobotId][]Position2d{robot3: {{X: 0, Y: 0}, {X: 3, Y: 0}}}); len(events) != 0 {
+		t.Errorf("Expected the duplicate to continue, got %v", events)
+	}
+
+	if events := stats.Prune(tNext.Add(2 * time.Second)); len(events) != 1 || len(stats.Active) != 0 {
+		t.Errorf("Expected the duplicate to end, got %v", events)
+	}
+	snapshot := stats.Snapshot()
+	if len(snapshot) != 1 || snapshot[0].Active || snapshot[0].Duration != 480*time.Millisecond || len(snapshot[0].CameraIds) != 2 {
+		t.Errorf("Expected an ended duplicate of 480ms on two cameras, got %v", snapshot)
+	}
+
+	// a single frame with two robots of the same id
+	tNext = tNext.Add(3 * time.Second)
+	if events := stats.AddFrame(0, tNext, map[RobotId][]Position2d{robot3: {{X: 1, Y: 1}, {X: -1, Y: 1}}}); len(events) != 1 {
+		t.Errorf("Expected a duplicate within a frame, got %v", events)
+	}
+	if stats.NumDuplicates[0] != 2 || stats.NumDuplicates[1] != 1 {
+		t.Errorf("Expected 2 duplicates for camera 0 and 1 for camera 1, got %v", stats.NumDuplicates)
+	}
+}
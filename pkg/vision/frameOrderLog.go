@@ -0,0 +1,53 @@
+package vision
+
+import (
+	"fmt"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"strings"
+	"time"
+)
+
+// frameOrderLogInterval is the minimum time between two log entries about lost, duplicated or reordered frames of a camera
+const frameOrderLogInterval = time.Second
+
+// frameOrderLog counts lost, duplicated and reordered frames of a camera between two log entries
+type frameOrderLog struct {
+	tLogged       time.Time
+	numGaps       int
+	numLost       int
+	numDuplicates int
+	numReordered  int
+}
+
+// add counts the given frame and returns a summary of all counted frames once frameOrderLogInterval passed since the last summary
+func (l *frameOrderLog) add(t time.Time, order timing.FrameOrder, numLost int) (summary string, ok bool) {
+	switch order {
+	case timing.FrameGap:
+		l.numGaps++
+		l.numLost += numLost
+	case timing.FrameDuplicate:
+		l.numDuplicates++
+	case timing.FrameReordered:
+		l.numReordered++
+	}
+	if l.numGaps+l.numDuplicates+l.numReordered == 0 {
+		return "", false
+	}
+	// the time may also jump back, for example after a restart of ssl-vision
+	if elapsed := t.Sub(l.tLogged); elapsed >= 0 && elapsed < frameOrderLogInterval {
+		return "", false
+	}
+
+	var parts []string
+	if l.numGaps > 0 {
+		parts = append(parts, fmt.Sprintf("gaps: %d with %d lost frames", l.numGaps, l.numLost))
+	}
+	if l.numDuplicates > 0 {
+		parts = append(parts, fmt.Sprintf("duplicates: %d", l.numDuplicates))
+	}
+	if l.numReordered > 0 {
+		parts = append(parts, fmt.Sprintf("reordered: %d", l.numReordered))
+	}
+	*l = frameOrderLog{tLogged: t}
+	return strings.Join(parts, ", "), true
+}
@@ -0,0 +1,31 @@
+package vision
+
+import (
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"testing"
+	"time"
+)
+
+func TestFrameOrderLog_Add(t *testing.T) {
+	var l frameOrderLog
+	tStart := time.Unix(1000, 0)
+	if _, ok := l.add(tStart, timing.FrameInOrder, 0); ok {
+		t.Error("Unexpected summary without lost frames")
+	}
+	if summary, ok := l.add(tStart, timing.FrameGap, 2); !ok || summary != "gaps: 1 with 2 lost frames" {
+		t.Errorf("Expected the first gap to be logged immediately, got '%v'", summary)
+	}
+
+	// frames within the interval are only counted
+	for i := 1; i <= 10; i++ {
+		tFrame := tStart.Add(time.Duration(i) * 10 * time.Millisecond)
+		if _, ok := l.add(tFrame, timing.FrameDuplicate, 0); ok {
+			t.Fatalf("Unexpected summary at %v", tFrame)
+		}
+	}
+	l.add(tStart.Add(500*time.Millisecond), timing.FrameGap, 3)
+	summary, ok := l.add(tStart.Add(time.Second), timing.FrameInOrder, 0)
+	if expected := "gaps: 1 with 3 lost frames, duplicates: 10"; !ok || summary != expected {
+		t.Errorf("Expected '%v', got '%v'", expected, summary)
+	}
+}
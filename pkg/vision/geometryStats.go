@@ -0,0 +1,237 @@
+package vision
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"math"
+	"sort"
+	"time"
+)
+
+// GeometryStats tracks the geometry data published by ssl-vision
+type GeometryStats struct {
+	Field        *SSL_GeometryFieldSize
+	Calibrations map[int]*SSL_GeometryCameraCalibration
+	// Models contains the camera model for each calibration, a model is only replaced when the calibration changes
+	Models        map[int]*CameraModel
+	FirstReceived time.Time
+	LastReceived  time.Time
+	NumPackets    int
+	NumChanges    int
+}
+
+type GeometrySnapshot struct {
+	Received           bool                `json:"received"`
+	LastReceived       time.Time           `json:"lastReceived"`
+	NumPackets         int                 `json:"numPackets"`
+	NumChanges         int                 `json:"numChanges"`
+	Field              *FieldSnapshot      `json:"field"`
+	Cameras            []CalibrationReport `json:"cameras"`
+	MissingCalibration []int               `json:"missingCalibration"`
+	// Ignored counts the geometry packets of each source that is not the primary source of any camera
+	Ignored map[string]int `json:"ignored"`
+}
+
+type FieldSnapshot struct {
+	FieldLength   int32               `json:"fieldLength"`
+	FieldWidth    int32               `json:"fieldWidth"`
+	GoalWidth     int32               `json:"goalWidth"`
+	GoalDepth     int32               `json:"goalDepth"`
+	BoundaryWidth int32               `json:"boundaryWidth"`
+	Lines         []FieldLineSnapshot `json:"lines"`
+	Arcs          []FieldArcSnapshot  `json:"arcs"`
+}
+
+type FieldLineSnapshot struct {
+	Name      string     `json:"name"`
+	P1        Position2d `json:"p1"`
+	P2        Position2d `json:"p2"`
+	Thickness float32    `json:"thickness"`
+}
+
+type FieldArcSnapshot struct {
+	Name      string     `json:"name"`
+	Center    Position2d `json:"center"`
+	Radius    float32    `json:"radius"`
+	A1        float32    `json:"a1"`
+	A2        float32    `json:"a2"`
+	Thickness float32    `json:"thickness"`
+}
+
+// CalibrationReport summarizes the calibration of a single camera, lengths are in millimeters
+type CalibrationReport struct {
+	CameraId       int        `json:"cameraId"`
+	FocalLength    float32    `json:"focalLength"`
+	PrincipalPoint Position2d `json:"principalPoint"`
+	Distortion     float32    `json:"distortion"`
+	Quaternion     [4]float32 `json:"quaternion"`
+	Translation    Vector3    `json:"translation"`
+	WorldPosition  Vector3    `json:"worldPosition"`
+}
+
+func NewGeometryStats() (s *GeometryStats) {
+	s = new(GeometryStats)
+	s.Calibrations = map[int]*SSL_GeometryCameraCalibration{}
+	s.Models = map[int]*CameraModel{}
+	return s
+}
+
+// Add updates the geometry and returns a description for each change compared to the previous geometry
+func (s *GeometryStats) Add(tReceived time.Time, geometry *SSL_GeometryData) (changes []string) {
+	if s.NumPackets == 0 {
+		s.FirstReceived = tReceived
+	}
+	s.NumPackets++
+	s.LastReceived = tReceived
+
+	if s.Field == nil {
+		changes = append(changes, "Received field geometry: "+fieldSizeString(geometry.Field))
+	} else if !proto.Equal(s.Field, geometry.Field) {
+		changes = append(changes, fmt.Sprintf("Field geometry changed from %v to %v",
+			fieldSizeString(s.Field), fieldSizeString(geometry.Field)))
+	}
+	s.Field = geometry.Field
+
+	calibrations := map[int]*SSL_GeometryCameraCalibration{}
+	models := map[int]*CameraModel{}
+	for _, calib := range geometry.Calib {
+		camId := int(calib.GetCameraId())
+		calibrations[camId] = calib
+		models[camId] = s.Models[camId]
+		if oldCalib, ok := s.Calibrations[camId]; !ok {
+			changes = append(changes, fmt.Sprintf("Received calibration for camera %v", camId))
+			models[camId] = newCameraModelPtr(calib)
+		} else if !proto.Equal(oldCalib, calib) {
+			changes = append(changes, fmt.Sprintf("Calibration of camera %v changed, camera position moved by %.0fmm",
+				camId, distance(CameraWorldPosition(oldCalib), CameraWorldPosition(calib))))
+			models[camId] = newCameraModelPtr(calib)
+		}
+	}
+	for _, camId := range sortedKeys(s.Calibrations) {
+		if _, ok := calibrations[camId]; !ok {
+			changes = append(changes, fmt.Sprintf("Calibration of camera %v was removed", camId))
+		}
+	}
+	s.Calibrations = calibrations
+	s.Models = models
+
+	if s.NumPackets > 1 {
+		// the initial geometry is not a change
+		s.NumChanges += len(changes)
+	}
+	return
+}
+
+// MissingCalibrations returns the ids of all given cameras that have no calibration
+func (s *GeometryStats) MissingCalibrations(camIds []int) (missing []int) {
+	missing = []int{}
+	for _, camId := range camIds {
+		if _, ok := s.Calibrations[camId]; !ok {
+			missing = append(missing, camId)
+		}
+	}
+	return
+}
+
+func (s *GeometryStats) Snapshot(camIds []int) (snapshot GeometrySnapshot) {
+	snapshot.Received = s.NumPackets > 0
+	snapshot.LastReceived = s.LastReceived
+	snapshot.NumPackets = s.NumPackets
+	snapshot.NumChanges = s.NumChanges
+	snapshot.MissingCalibration = s.MissingCalibrations(camIds)
+	snapshot.Cameras = []CalibrationReport{}
+	for _, camId := range sortedKeys(s.Calibrations) {
+		snapshot.Cameras = append(snapshot.Cameras, NewCalibrationReport(s.Calibrations[camId]))
+	}
+	if s.Field != nil {
+		snapshot.Field = newFieldSnapshot(s.Field)
+	}
+	return
+}
+
+func newFieldSnapshot(field *SSL_GeometryFieldSize) (s *FieldSnapshot) {
+	s = new(FieldSnapshot)
+	s.FieldLength = field.GetFieldLength()
+	s.FieldWidth = field.GetFieldWidth()
+	s.GoalWidth = field.GetGoalWidth()
+	s.GoalDepth = field.GetGoalDepth()
+	s.BoundaryWidth = field.GetBoundaryWidth()
+	s.Lines = []FieldLineSnapshot{}
+	for _, line := range field.FieldLines {
+		s.Lines = append(s.Lines, FieldLineSnapshot{
+			Name:      line.GetName(),
+			P1:        Position2d{X: line.GetP1().GetX(), Y: line.GetP1().GetY()},
+			P2:        Position2d{X: line.GetP2().GetX(), Y: line.GetP2().GetY()},
+			Thickness: line.GetThickness(),
+		})
+	}
+	s.Arcs = []FieldArcSnapshot{}
+	for _, arc := range field.FieldArcs {
+		s.Arcs = append(s.Arcs, FieldArcSnapshot{
+			Name:      arc.GetName(),
+			Center:    Position2d{X: arc.GetCenter().GetX(), Y: arc.GetCenter().GetY()},
+			Radius:    arc.GetRadius(),
+			A1:        arc.GetA1(),
+			A2:        arc.GetA2(),
+			Thickness: arc.GetThickness(),
+		})
+	}
+	return
+}
+
+func NewCalibrationReport(calib *SSL_GeometryCameraCalibration) (r CalibrationReport) {
+	r.CameraId = int(calib.GetCameraId())
+	r.FocalLength = calib.GetFocalLength()
+	r.PrincipalPoint = Position2d{X: calib.GetPrincipalPointX(), Y: calib.GetPrincipalPointY()}
+	r.Distortion = calib.GetDistortion()
+	r.Quaternion = [4]float32{calib.GetQ0(), calib.GetQ1(), calib.GetQ2(), calib.GetQ3()}
+	r.Translation = Vector3{X: float64(calib.GetTx()), Y: float64(calib.GetTy()), Z: float64(calib.GetTz())}
+	r.WorldPosition = CameraWorldPosition(calib)
+	return
+}
+
+func (r CalibrationReport) String() string {
+	return fmt.Sprintf("f: %6.1f | c: %5.1f/%5.1f | d: %6.3f | pos: %5.0f/%5.0f/%5.0f",
+		r.FocalLength, r.PrincipalPoint.X, r.PrincipalPoint.Y, r.Distortion,
+		r.WorldPosition.X, r.WorldPosition.Y, r.WorldPosition.Z)
+}
+
+func (s GeometryStats) String() string {
+	if s.Field == nil {
+		return "No geometry received\n"
+	}
+	str := fmt.Sprintf("Field: %v | %v lines | %v arcs | %v changes\n",
+		fieldSizeString(s.Field), len(s.Field.FieldLines), len(s.Field.FieldArcs), s.NumChanges)
+	for _, camId := range sortedKeys(s.Calibrations) {
+		str += fmt.Sprintf("Camera %2d: %v\n", camId, NewCalibrationReport(s.Calibrations[camId]))
+	}
+	return str
+}
+
+func fieldSizeString(field *SSL_GeometryFieldSize) string {
+	return fmt.Sprintf("%vx%vmm (goal %vx%vmm, boundary %vmm)",
+		field.GetFieldLength(), field.GetFieldWidth(),
+		field.GetGoalWidth(), field.GetGoalDepth(),
+		field.GetBoundaryWidth())
+}
+
+func newCameraModelPtr(calib *SSL_GeometryCameraCalibration) *CameraModel {
+	model := NewCameraModel(calib)
+	return &model
+}
+
+func distance(a, b Vector3) float64 {
+	dx := a.X - b.X
+	dy := a.Y - b.Y
+	dz := a.Z - b.Z
+	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+}
+
+func sortedKeys[V any](m map[int]V) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
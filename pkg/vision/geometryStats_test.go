@@ -0,0 +1,122 @@
+package vision
+
+import (
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"google.golang.org/protobuf/proto"
+	"math"
+	"testing"
+	"time"
+)
+
+func testCalibration(camId uint32, tx float32) *SSL_GeometryCameraCalibration {
+	return &SSL_GeometryCameraCalibration{
+		CameraId:        proto.Uint32(camId),
+		FocalLength:     proto.Float32(500),
+		PrincipalPointX: proto.Float32(390),
+		PrincipalPointY: proto.Float32(290),
+		Distortion:      proto.Float32(0),
+		// rotated by 180° around the x-axis, looking down to the field
+		Q0: proto.Float32(1),
+		Q1: proto.Float32(0),
+		Q2: proto.Float32(0),
+		Q3: proto.Float32(0),
+		Tx: proto.Float32(tx),
+		Ty: proto.Float32(0),
+		Tz: proto.Float32(4000),
+	}
+}
+
+func testGeometry(fieldLength int32, calibs ...*SSL_GeometryCameraCalibration) *SSL_GeometryData {
+	return &SSL_GeometryData{
+		Field: &SSL_GeometryFieldSize{
+			FieldLength:   proto.Int32(fieldLength),
+			FieldWidth:    proto.Int32(9000),
+			GoalWidth:     proto.Int32(1800),
+			GoalDepth:     proto.Int32(180),
+			BoundaryWidth: proto.Int32(300),
+		},
+		Calib: calibs,
+	}
+}
+
+func TestCameraWorldPosition(t *testing.T) {
+	pos := CameraWorldPosition(testCalibration(0, 1000))
+	if math.Abs(pos.X+1000) > 1e-3 || math.Abs(pos.Y) > 1e-3 || math.Abs(pos.Z-4000) > 1e-3 {
+		t.Errorf("Camera position %v != (-1000, 0, 4000)", pos)
+	}
+}
+
+func TestGeometryStats_Add(t *testing.T) {
+	stats := NewGeometryStats()
+	tNow := time.Now()
+
+	changes := stats.Add(tNow, testGeometry(12000, testCalibration(0, 1000)))
+	if len(changes) != 2 || stats.NumChanges != 0 {
+		t.Errorf("Initial geometry should report field and camera, but got %v with %v changes", changes, stats.NumChanges)
+	}
+
+	changes = stats.Add(tNow, testGeometry(12000, testCalibration(0, 1000)))
+	if len(changes) != 0 {
+		t.Errorf("Unchanged geometry reported changes: %v", changes)
+	}
+
+	changes = stats.Add(tNow, testGeometry(9000, testCalibration(0, 1100), testCalibration(1, 0)))
+	if len(changes) != 3 || stats.NumChanges != 3 {
+		t.Errorf("Expected 3 changes, got %v", changes)
+	}
+
+	missing := stats.MissingCalibrations([]int{0, 1, 2})
+	if len(missing) != 1 || missing[0] != 2 {
+		t.Errorf("Missing calibrations %v != [2]", missing)
+	}
+}
+
+func TestCameraModel_FieldToImage(t *testing.T) {
+	model := NewCameraModel(testCalibration(0, 1000))
+	x, y, ok := model.FieldToImage(Vector3{X: 0, Y: 0, Z: 0})
+	if !ok || math.Abs(x-515) > 1e-3 || math.Abs(y-290) > 1e-3 {
+		t.Errorf("Projected point %v/%v (%v) != 515/290", x, y, ok)
+	}
+
+	stats := NewReprojectionStats(time.Second)
+	stats.SetModel(&model)
+	tNow := time.Unix(1000, 0)
+	stats.Add(tNow, Vector3{X: 0, Y: 0, Z: 0}, 518, 294)
+	if mean := stats.Snapshot().Error.Mean; math.Abs(mean-5) > 1e-3 {
+		t.Errorf("Reprojection error %v != 5", mean)
+	}
+
+	stats.Prune(tNow.Add(2 * time.Second))
+	if n := stats.Snapshot().Error.NumSamples; n != 0 {
+		t.Errorf("Expected no samples after the time window, got %v", n)
+	}
+}
+
+func TestStats_ProcessGeometry(t *testing.T) {
+	clock := timing.NewManualClock(time.Unix(1000, 0))
+	stats := NewStats(StatsConfig{Clock: clock, TimeWindowVisibility: time.Second})
+	primary := "10.0.0.1"
+	team := "10.0.0.99"
+
+	stats.Process(primary, &SSL_WrapperPacket{Detection: &SSL_DetectionFrame{
+		FrameNumber: proto.Uint32(1),
+		TCapture:    proto.Float64(1000),
+		TSent:       proto.Float64(1000),
+		CameraId:    proto.Uint32(0),
+	}})
+	stats.Process(primary, &SSL_WrapperPacket{Geometry: testGeometry(12000, testCalibration(0, 1000))})
+	for i := 0; i < 3; i++ {
+		stats.Process(team, &SSL_WrapperPacket{Geometry: testGeometry(9000, testCalibration(0, 2000))})
+		stats.Process(primary, &SSL_WrapperPacket{Geometry: testGeometry(12000, testCalibration(0, 1000))})
+	}
+
+	if stats.Geometry.NumChanges != 0 || stats.Geometry.NumPackets != 4 {
+		t.Errorf("Expected 4 unchanged geometry packets, got %v packets with %v changes", stats.Geometry.NumPackets, stats.Geometry.NumChanges)
+	}
+	if tx := stats.Geometry.Calibrations[0].GetTx(); tx != 1000 {
+		t.Errorf("Expected the calibration of the primary source, got tx %v", tx)
+	}
+	if stats.IgnoredGeometry[team] != 3 {
+		t.Errorf("Expected 3 ignored geometry packets of %v, got %v", team, stats.IgnoredGeometry)
+	}
+}
@@ -0,0 +1,180 @@
+package vision
+
+import (
+	"fmt"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"sort"
+	"time"
+)
+
+// maxIdFlickerDuration is the maximum duration of a different id on a robot track to be counted as a flicker instead of a change
+const maxIdFlickerDuration = 500 * time.Millisecond
+
+// maxPhantomDistance is the maximum distance in meters of two robot detections in the same frame to be considered the same robot
+const maxPhantomDistance = 0.05
+
+// IdEvent describes a change of the robot id of a track
+type IdEvent string
+
+const (
+	IdNone IdEvent = ""
+	// IdFlicker is a different id for a short time, after which the original id was detected again
+	IdFlicker IdEvent = "flicker"
+	// IdChange is a different id that was detected for longer than maxIdFlickerDuration
+	IdChange IdEvent = "change"
+)
+
+// IdStats analyses the robot ids that are detected for a single robot track
+type IdStats struct {
+	// NumChanges counts ids that changed permanently
+	NumChanges int
+	// NumFlickers counts ids that changed for less than maxIdFlickerDuration
+	NumFlickers int
+	// NumPhantoms counts detections of another id at the position of this robot in the same frame
+	NumPhantoms int
+	// Misreads counts the ids that were detected instead of the id of the track by flickers and phantoms
+	Misreads map[RobotId]int
+	// samples holds the id of each detection within the time window
+	samples *timing.TimeWindow[RobotId]
+	counts  map[RobotId]int
+	last    RobotId
+	hasLast bool
+	pending *pendingIdChange
+}
+
+// pendingIdChange is a change of the id that is not yet known to be a flicker or a change
+type pendingIdChange struct {
+	from   RobotId
+	tStart time.Time
+	ids    []RobotId
+}
+
+type IdSnapshot struct {
+	// Stability is the fraction of detections within the time window with the most frequent id
+	Stability     float64             `json:"stability"`
+	NumDetections int                 `json:"numDetections"`
+	NumChanges    int                 `json:"numChanges"`
+	NumFlickers   int                 `json:"numFlickers"`
+	NumPhantoms   int                 `json:"numPhantoms"`
+	Misreads      []IdMisreadSnapshot `json:"misreads"`
+}
+
+type IdMisreadSnapshot struct {
+	Id    int       `json:"id"`
+	Color TeamColor `json:"color"`
+	Count int       `json:"count"`
+}
+
+func NewIdStats(timeWindow time.Duration) (s *IdStats) {
+	s = new(IdStats)
+	s.Misreads = map[RobotId]int{}
+	s.counts = map[RobotId]int{}
+	s.samples = timing.NewTimeWindow[RobotId](timeWindow)
+	return s
+}
+
+// Add adds the id of a detection and returns a flicker or change of the id from one id to another.
+// For a flicker, to is the misread id.
+func (s *IdStats) Add(t time.Time, id RobotId) (event IdEvent, from RobotId, to RobotId) {
+	s.addSample(t, id)
+	if !s.hasLast {
+		s.last = id
+		s.hasLast = true
+		return
+	}
+
+	if s.pending != nil && t.Sub(s.pending.tStart) > maxIdFlickerDuration {
+		s.NumChanges++
+		event = IdChange
+		from = s.pending.from
+		to = s.pending.ids[0]
+		s.pending = nil
+	}
+	if s.pending != nil {
+		if id == s.pending.from {
+			s.NumFlickers++
+			for _, misread := range s.pending.ids {
+				s.Misreads[misread]++
+			}
+			event = IdFlicker
+			from = s.pending.from
+			to = s.pending.ids[0]
+			s.pending = nil
+		} else if id != s.last {
+			s.pending.ids = append(s.pending.ids, id)
+		}
+	} else if id != s.last {
+		s.pending = &pendingIdChange{from: s.last, tStart: t, ids: []RobotId{id}}
+	}
+	s.last = id
+	return
+}
+
+// AddPhantom adds a detection of another id at the position of this robot
+func (s *IdStats) AddPhantom(t time.Time, id RobotId) {
+	s.NumPhantoms++
+	s.Misreads[id]++
+	s.addSample(t, id)
+}
+
+func (s *IdStats) addSample(t time.Time, id RobotId) {
+	s.samples.Add(t, id)
+	s.counts[id]++
+	s.samples.PruneFunc(t, func(old RobotId) {
+		s.counts[old]--
+		if s.counts[old] == 0 {
+			delete(s.counts, old)
+		}
+	})
+}
+
+// Dominant returns the most frequent id within the time window and its number of detections
+func (s *IdStats) Dominant() (dominant RobotId, count int) {
+	for id, c := range s.counts {
+		if c > count || (c == count && (id.Color < dominant.Color || (id.Color == dominant.Color && id.Id < dominant.Id))) {
+			dominant = id
+			count = c
+		}
+	}
+	return
+}
+
+// Stability returns the fraction of detections within the time window with the most frequent id
+func (s *IdStats) Stability() float64 {
+	if s.samples.Len() == 0 {
+		return 1
+	}
+	_, count := s.Dominant()
+	return float64(count) / float64(s.samples.Len())
+}
+
+func (s *IdStats) String() string {
+	return fmt.Sprintf("id stability %3.0f%% | %v changes | %v flickers | %v phantoms",
+		s.Stability()*100, s.NumChanges, s.NumFlickers, s.NumPhantoms)
+}
+
+func (s *IdStats) Snapshot() (snapshot IdSnapshot) {
+	snapshot.Stability = s.Stability()
+	snapshot.NumDetections = s.samples.Len()
+	snapshot.NumChanges = s.NumChanges
+	snapshot.NumFlickers = s.NumFlickers
+	snapshot.NumPhantoms = s.NumPhantoms
+	snapshot.Misreads = []IdMisreadSnapshot{}
+	for id, count := range s.Misreads {
+		snapshot.Misreads = append(snapshot.Misreads, IdMisreadSnapshot{Id: id.Id, Color: id.Color, Count: count})
+	}
+	sort.Slice(snapshot.Misreads, func(i, j int) bool {
+		if snapshot.Misreads[i].Count != snapshot.Misreads[j].Count {
+			return snapshot.Misreads[i].Count > snapshot.Misreads[j].Count
+		}
+		if snapshot.Misreads[i].Color != snapshot.Misreads[j].Color {
+			return snapshot.Misreads[i].Color < snapshot.Misreads[j].Color
+		}
+		return snapshot.Misreads[i].Id < snapshot.Misreads[j].Id
+	})
+	return
+}
+
+func (s IdMisreadSnapshot) String() string {
+	return fmt.Sprintf("%v%d: %v", s.Color, s.Id, s.Count)
+}
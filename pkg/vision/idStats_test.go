@@ -0,0 +1,142 @@
+package vision
+
+import (
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"google.golang.org/protobuf/proto"
+	"testing"
+	"time"
+)
+
+func TestIdStats_Add(t *testing.T) {
+	stats := NewIdStats(10 * time.Second)
+	tStart := time.Unix(1000, 0)
+	robot3 := NewRobotId(3, TeamBlue)
+	robot5 := NewRobotId(5, TeamBlue)
+	frame := func(i int) time.Time {
+		return tStart.Add(time.Duration(i) * 100 * time.Millisecond)
+	}
+
+	for i := 0; i < 10; i++ {
+		if event, _, _ := stats.Add(frame(i), robot3); event != IdNone {
+			t.Errorf("Unexpected event %v at frame %v", event, i)
+		}
+	}
+	// misread for two frames
+	stats.Add(frame(10), robot5)
+	stats.Add(frame(11), robot5)
+	if event, from, to := stats.Add(frame(12), robot3); event != IdFlicker || from != robot3 || to != robot5 {
+		t.Errorf("Expected a flicker from %v to %v, got %v from %v to %v", robot3, robot5, event, from, to)
+	}
+	if stats.Misreads[robot5] != 1 {
+		t.Errorf("Expected a misread of %v, got %v", robot5, stats.Misreads)
+	}
+
+	// permanent change
+	for i := 13; i < 20; i++ {
+		if event, from, to := stats.Add(frame(i), robot5); i == 19 && (event != IdChange || from != robot3 || to != robot5) {
+			t.Errorf("Expected a change from %v to %v, got %v from %v to %v", robot3, robot5, event, from, to)
+		}
+	}
+	if stats.NumFlickers != 1 || stats.NumChanges != 1 {
+		t.Errorf("Expected 1 flicker and 1 change, got %v and %v", stats.NumFlickers, stats.NumChanges)
+	}
+	if dominant, count := stats.Dominant(); dominant != robot3 || count != 11 {
+		t.Errorf("Expected %v with 11 detections to be dominant, got %v with %v", robot3, dominant, count)
+	}
+	if s := stats.Stability(); s != 11.0/20 {
+		t.Errorf("Expected a stability of %v, got %v", 11.0/20, s)
+	}
+
+	stats.AddPhantom(frame(19), robot3)
+	if stats.NumPhantoms != 1 || stats.Misreads[robot3] != 1 {
+		t.Errorf("Expected a phantom of %v, got %v", robot3, stats.Misreads)
+	}
+}
+
+func TestCamStats_GetRobotStats(t *testing.T) {
+	tStart := time.Unix(1000, 0)
+	camStats := NewCamStats(StatsConfig{
+		TimeWindowQualityRobot: time.Second,
+		TimeWindowRobotIds:     time.Second,
+		Clock:                  timing.NewManualClock(tStart),
+	})
+	robot3 := NewRobotId(3, TeamBlue)
+	robot5 := NewRobotId(5, TeamBlue)
+
+	track3 := camStats.GetRobotStats(robot3, tStart, Position2d{X: 0})
+	track3.Add(tStart, 1, Position2d{X: 0})
+	track3.Ids.Add(tStart, robot3)
+	track5 := camStats.GetRobotStats(robot5, tStart, Position2d{X: 0.02})
+	track5.Add(tStart, 1, Position2d{X: 0.02})
+	track5.Ids.Add(tStart, robot5)
+	if track3 == track5 || camStats.RobotAtPosition(track5) != track3 {
+		t.Errorf("Expected two tracks at the same position")
+	}
+
+	// both tracks are close enough, but the track with the same id is preferred
+	tNext := tStart.Add(10 * time.Millisecond)
+	if track := camStats.GetRobotStats(robot5, tNext, Position2d{X: 0}); track != track5 {
+		t.Errorf("Expected the track of %v, got %v", robot5, track.Id)
+	}
+}
+
+func TestStats_Phantom(t *testing.T) {
+	stats := NewStats(StatsConfig{
+		TimeWindowVisibility:   time.Second,
+		TimeWindowQualityRobot: time.Second,
+		TimeWindowRobotIds:     time.Second,
+		Clock:                  timing.NewManualClock(time.Unix(1000, 0)),
+	})
+	process := func(frameId int, robotIds ...uint32) {
+		frame := &SSL_DetectionFrame{
+			FrameNumber: proto.Uint32(uint32(frameId)),
+			TCapture:    proto.Float64(1000 + float64(frameId)*0.01),
+			TSent:       proto.Float64(1000 + float64(frameId)*0.01),
+			CameraId:    proto.Uint32(0),
+		}
+		for _, robotId := range robotIds {
+			frame.RobotsBlue = append(frame.RobotsBlue, &SSL_DetectionRobot{
+				Confidence: proto.Float32(1),
+				RobotId:    proto.Uint32(robotId),
+				X:          proto.Float32(0),
+				Y:          proto.Float32(0),
+				PixelX:     proto.Float32(100),
+				PixelY:     proto.Float32(100),
+			})
+		}
+		stats.Process("10.0.0.1", &SSL_WrapperPacket{Detection: frame})
+	}
+	phantom := func() bool {
+		for _, robot := range stats.CamStats[CamKey{Source: "10.0.0.1", CamId: 0}].Robots[TeamBlue] {
+			if robot.Id == NewRobotId(5, TeamBlue) {
+				return robot.Phantom
+			}
+		}
+		t.Fatal("Missing the track of robot 5")
+		return false
+	}
+
+	for i := 0; i < 10; i++ {
+		process(i, 3)
+	}
+	for i := 10; i < 20; i++ {
+		process(i, 3, 5)
+	}
+	if !phantom() {
+		t.Error("Expected robot 5 to be a phantom at the position of robot 3")
+	}
+
+	// robot 3 is not detected anymore and robot 5 stays the only robot at the position
+	for i := 20; i < 100; i++ {
+		process(i, 5)
+	}
+	if !phantom() {
+		t.Error("Expected robot 5 to stay a phantom within the time window")
+	}
+	for i := 100; i < 130; i++ {
+		process(i, 5)
+	}
+	if phantom() {
+		t.Error("Expected robot 5 to be no phantom after the time window")
+	}
+}
@@ -0,0 +1,54 @@
+package vision
+
+import (
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+)
+
+// logCapacity is the number of most recent log entries that are kept
+const logCapacity = 10000
+
+// LogList keeps the most recent log entries. Entries are numbered consecutively,
+// so that readers can continue at the next entry, even if older entries were dropped.
+type LogList struct {
+	entries  timing.Ring[string]
+	capacity int
+	// next is the number of the next entry, which is the total number of added entries
+	next int
+}
+
+func NewLogList(capacity int) (l *LogList) {
+	l = new(LogList)
+	l.capacity = capacity
+	return l
+}
+
+// Add adds an entry and drops the oldest entry if the capacity is exceeded
+func (l *LogList) Add(entry string) {
+	l.entries.PushBack(entry)
+	if l.entries.Len() > l.capacity {
+		l.entries.PopFront()
+	}
+	l.next++
+}
+
+// Since returns all kept entries starting at the given number and the number of the next entry
+func (l *LogList) Since(index int) ([]string, int) {
+	oldest := l.next - l.entries.Len()
+	if index < oldest {
+		index = oldest
+	}
+	if index > l.next {
+		index = l.next
+	}
+	entries := make([]string, 0, l.next-index)
+	for i := index - oldest; i < l.entries.Len(); i++ {
+		entries = append(entries, l.entries.At(i))
+	}
+	return entries, l.next
+}
+
+// Latest returns the n most recent entries
+func (l *LogList) Latest(n int) []string {
+	entries, _ := l.Since(l.next - n)
+	return entries
+}
@@ -0,0 +1,35 @@
+package vision
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestLogList_Since(t *testing.T) {
+	logList := NewLogList(5)
+	for i := 0; i < 3; i++ {
+		logList.Add(strconv.Itoa(i))
+	}
+	entries, next := logList.Since(1)
+	if len(entries) != 2 || entries[0] != "1" || next != 3 {
+		t.Errorf("Expected entries 1 and 2, got %v with next %v", entries, next)
+	}
+
+	for i := 3; i < 10; i++ {
+		logList.Add(strconv.Itoa(i))
+	}
+	// entries 3 and 4 were dropped
+	entries, next = logList.Since(next)
+	if len(entries) != 5 || entries[0] != "5" || entries[4] != "9" || next != 10 {
+		t.Errorf("Expected entries 5 to 9, got %v with next %v", entries, next)
+	}
+	if entries, next = logList.Since(next); len(entries) != 0 || next != 10 {
+		t.Errorf("Expected no new entries, got %v with next %v", entries, next)
+	}
+	if latest := logList.Latest(2); len(latest) != 2 || latest[0] != "8" {
+		t.Errorf("Expected entries 8 and 9, got %v", latest)
+	}
+	if latest := logList.Latest(20); len(latest) != 5 {
+		t.Errorf("Expected all 5 kept entries, got %v", latest)
+	}
+}
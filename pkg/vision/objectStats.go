@@ -10,7 +10,11 @@ type ObjectStats struct {
 	FrameStats     *timing.FrameStats
 	FirstDetection Detection
 	LastDetection  Detection
-	timeWindow     time.Duration
+	// Stationary is optional and may be nil
+	Stationary *StationaryStats
+	// Blob is only set for balls
+	Blob       *BlobStats
+	timeWindow time.Duration
 }
 
 type Detection struct {
@@ -18,9 +22,9 @@ type Detection struct {
 	Pos  Position2d
 }
 
-func NewObjectStats(detection Detection, timeWindow time.Duration) (s *ObjectStats) {
+func NewObjectStats(detection Detection, timeWindow time.Duration, clock timing.Clock) (s *ObjectStats) {
 	s = new(ObjectStats)
-	s.FrameStats = timing.NewFrameStats(timeWindow)
+	s.FrameStats = timing.NewFrameStats(timeWindow, clock)
 	s.FirstDetection = detection
 	s.LastDetection = detection
 
@@ -46,6 +50,9 @@ func (s *ObjectStats) Add(tSent time.Time, frameId uint32, pos Position2d) {
 	s.LastDetection.Time = tSent
 	s.LastDetection.Pos = pos
 	s.FrameStats.Add(frameId, tSent)
+	if s.Stationary != nil {
+		s.Stationary.Add(tSent, pos)
+	}
 }
 
 func (s *ObjectStats) Prune(tSent time.Time) {
@@ -54,6 +61,12 @@ func (s *ObjectStats) Prune(tSent time.Time) {
 
 func (s *ObjectStats) Clear() {
 	s.FrameStats.Clear()
+	if s.Blob != nil {
+		s.Blob.Clear()
+	}
+	if s.Stationary != nil {
+		s.Stationary.Clear()
+	}
 }
 
 func (s *ObjectStats) Age() time.Duration {
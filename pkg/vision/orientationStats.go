@@ -0,0 +1,100 @@
+package vision
+
+import (
+	"fmt"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"math"
+	"time"
+)
+
+// flipTolerance is the maximum difference of an orientation change to π to be counted as a flip, in radians
+const flipTolerance = 30 * math.Pi / 180
+
+// orientationJumpThreshold is the orientation change between two frames above which the frames are considered to disagree, in radians
+const orientationJumpThreshold = 15 * math.Pi / 180
+
+// OrientationStats collects the orientation noise of a robot track
+type OrientationStats struct {
+	Orientation float64
+	// NumFlips counts orientation changes of roughly π between two frames, which indicate a misread pattern
+	NumFlips int
+	// NumJumps counts orientation changes between two frames above orientationJumpThreshold that are no flips
+	NumJumps int
+	hasLast  bool
+	// changes holds the orientation changes between two frames within the time window, excluding flips
+	changes *timing.TimeWindow[float64]
+}
+
+type OrientationSnapshot struct {
+	Orientation float64 `json:"orientation"`
+	// Noise is the estimated circular standard deviation of the orientation in radians
+	Noise float64 `json:"noise"`
+	// MaxChange is the largest orientation change between two frames within the time window, excluding flips
+	MaxChange float64 `json:"maxChange"`
+	NumFlips  int     `json:"numFlips"`
+	NumJumps  int     `json:"numJumps"`
+}
+
+func NewOrientationStats(timeWindow time.Duration) (s *OrientationStats) {
+	s = new(OrientationStats)
+	s.changes = timing.NewTimeWindow[float64](timeWindow)
+	return s
+}
+
+// Add adds the orientation of a frame and returns the orientation change, if it was a flip
+func (s *OrientationStats) Add(t time.Time, orientation float64) (flip bool, delta float64) {
+	if s.hasLast {
+		delta = normalizeAngle(orientation - s.Orientation)
+		if math.Abs(math.Abs(delta)-math.Pi) < flipTolerance {
+			s.NumFlips++
+			flip = true
+		} else {
+			if math.Abs(delta) > orientationJumpThreshold {
+				s.NumJumps++
+			}
+			s.changes.Add(t, delta)
+		}
+	}
+	s.Orientation = orientation
+	s.hasLast = true
+	s.changes.Prune(t)
+	return
+}
+
+// Noise returns the circular standard deviation of the orientation changes between two frames divided by √2,
+// which estimates the noise of a single orientation independent of a constant rotation of the robot
+func (s *OrientationStats) Noise() float64 {
+	if s.changes.Len() < 2 {
+		return 0
+	}
+	var sumSin, sumCos float64
+	for _, delta := range s.changes.All() {
+		sumSin += math.Sin(delta)
+		sumCos += math.Cos(delta)
+	}
+	n := float64(s.changes.Len())
+	r := math.Min(1, math.Hypot(sumSin/n, sumCos/n))
+	return math.Sqrt(math.Max(0, -2*math.Log(r))) / math.Sqrt2
+}
+
+// MaxChange returns the largest absolute orientation change between two frames within the time window, excluding flips
+func (s *OrientationStats) MaxChange() (max float64) {
+	for _, delta := range s.changes.All() {
+		max = math.Max(max, math.Abs(delta))
+	}
+	return
+}
+
+func (s *OrientationStats) String() string {
+	return fmt.Sprintf("θ %5.1f° σ %.1f° max Δ %.1f° | %v flips | %v jumps",
+		s.Orientation*180/math.Pi, s.Noise()*180/math.Pi, s.MaxChange()*180/math.Pi, s.NumFlips, s.NumJumps)
+}
+
+func (s *OrientationStats) Snapshot() (snapshot OrientationSnapshot) {
+	snapshot.Orientation = s.Orientation
+	snapshot.Noise = s.Noise()
+	snapshot.MaxChange = s.MaxChange()
+	snapshot.NumFlips = s.NumFlips
+	snapshot.NumJumps = s.NumJumps
+	return
+}
@@ -0,0 +1,38 @@
+package vision
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestOrientationStats_Add(t *testing.T) {
+	stats := NewOrientationStats(time.Second)
+	tStart := time.Unix(1000, 0)
+	noise := []float64{0.01, -0.01}
+	for i := 0; i < 20; i++ {
+		// a constant rotation across the wrap around at π with alternating noise
+		orientation := normalizeAngle(3 + float64(i)*0.05 + noise[i%2])
+		if flip, _ := stats.Add(tStart.Add(time.Duration(i)*10*time.Millisecond), orientation); flip {
+			t.Errorf("Unexpected flip at frame %v", i)
+		}
+	}
+	// the changes alternate between 0.07 and 0.03, so their standard deviation is 0.02
+	if n := stats.Noise(); math.Abs(n-0.02/math.Sqrt2) > 1e-3 {
+		t.Errorf("Expected a noise of %v, got %v", 0.02/math.Sqrt2, n)
+	}
+	if stats.NumJumps != 0 {
+		t.Errorf("Expected no jumps, got %v", stats.NumJumps)
+	}
+
+	flipped := normalizeAngle(stats.Orientation + math.Pi - 0.1)
+	if flip, delta := stats.Add(tStart.Add(time.Second), flipped); !flip || math.Abs(math.Abs(delta)-(math.Pi-0.1)) > 1e-9 {
+		t.Errorf("Expected a flip by %v, got %v with %v", math.Pi-0.1, flip, delta)
+	}
+	if flip, _ := stats.Add(tStart.Add(time.Second+10*time.Millisecond), normalizeAngle(flipped+0.5)); flip || stats.NumJumps != 1 {
+		t.Errorf("Expected a jump, got flip %v and %v jumps", flip, stats.NumJumps)
+	}
+	if stats.NumFlips != 1 || math.Abs(stats.MaxChange()-0.5) > 1e-9 {
+		t.Errorf("Expected 1 flip and a max change of 0.5, got %v and %v", stats.NumFlips, stats.MaxChange())
+	}
+}
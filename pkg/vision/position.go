@@ -6,8 +6,8 @@ import (
 )
 
 type Position2d struct {
-	X float32
-	Y float32
+	X float32 `json:"x"`
+	Y float32 `json:"y"`
 }
 
 func (p *Position2d) DistanceTo(pos Position2d) float64 {
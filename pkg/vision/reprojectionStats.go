@@ -0,0 +1,196 @@
+package vision
+
+import (
+	"fmt"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"math"
+	"time"
+)
+
+// Number of cells in each direction of the image for the spatial distribution of the reprojection error
+const reprojectionGridCols = 4
+const reprojectionGridRows = 3
+
+// Height of objects above the ground in millimeters, if not reported by ssl-vision
+const defaultBallHeight = 30.0
+const defaultRobotHeight = 140.0
+
+// ReprojectionStats collects the error between the reported pixel position of detections and
+// their world position projected into the image with the camera calibration within the time window
+type ReprojectionStats struct {
+	model   *CameraModel
+	samples *timing.TimeWindow[reprojectionSample]
+}
+
+type reprojectionSample struct {
+	err float64
+	row int
+	col int
+}
+
+// ErrorStats accumulates errors in pixels
+type ErrorStats struct {
+	NumSamples int
+	Sum        float64
+	SqSum      float64
+	Max        float64
+}
+
+type ReprojectionSnapshot struct {
+	Calibrated bool                 `json:"calibrated"`
+	Error      ErrorSnapshot        `json:"error"`
+	Grid       [][]ErrorSnapshot    `json:"grid"`
+	GridSize   ReprojectionGridSize `json:"gridSize"`
+}
+
+type ReprojectionGridSize struct {
+	Cols int `json:"cols"`
+	Rows int `json:"rows"`
+	// CellWidth and CellHeight are in pixels
+	CellWidth  float64 `json:"cellWidth"`
+	CellHeight float64 `json:"cellHeight"`
+}
+
+type ErrorSnapshot struct {
+	NumSamples int     `json:"numSamples"`
+	Mean       float64 `json:"mean"`
+	StdDev     float64 `json:"stdDev"`
+	Max        float64 `json:"max"`
+}
+
+func (s *ErrorStats) Add(err float64) {
+	s.NumSamples++
+	s.Sum += err
+	s.SqSum += err * err
+	if err > s.Max {
+		s.Max = err
+	}
+}
+
+func (s *ErrorStats) Mean() float64 {
+	if s.NumSamples == 0 {
+		return 0
+	}
+	return s.Sum / float64(s.NumSamples)
+}
+
+func (s *ErrorStats) StdDev() float64 {
+	if s.NumSamples == 0 {
+		return 0
+	}
+	mean := s.Mean()
+	return math.Sqrt(math.Max(0, s.SqSum/float64(s.NumSamples)-mean*mean))
+}
+
+func (s *ErrorStats) Snapshot() ErrorSnapshot {
+	return ErrorSnapshot{
+		NumSamples: s.NumSamples,
+		Mean:       s.Mean(),
+		StdDev:     s.StdDev(),
+		Max:        s.Max,
+	}
+}
+
+func NewReprojectionStats(timeWindow time.Duration) (s *ReprojectionStats) {
+	s = new(ReprojectionStats)
+	s.samples = timing.NewTimeWindow[reprojectionSample](timeWindow)
+	return s
+}
+
+// SetModel sets the camera model for the reprojection and resets the statistics if the model changed
+func (s *ReprojectionStats) SetModel(model *CameraModel) {
+	if s.model != model {
+		s.model = model
+		s.samples.Clear()
+	}
+}
+
+// Add reprojects the world position and adds the distance to the pixel position
+func (s *ReprojectionStats) Add(t time.Time, world Vector3, pixelX, pixelY float32) {
+	if s.model == nil {
+		return
+	}
+	x, y, ok := s.model.FieldToImage(world)
+	if !ok {
+		return
+	}
+	dx := x - float64(pixelX)
+	dy := y - float64(pixelY)
+	cellWidth, cellHeight := s.cellSize()
+	s.samples.Add(t, reprojectionSample{
+		err: math.Sqrt(dx*dx + dy*dy),
+		row: clampIndex(int(float64(pixelY)/cellHeight), reprojectionGridRows),
+		col: clampIndex(int(float64(pixelX)/cellWidth), reprojectionGridCols),
+	})
+}
+
+func (s *ReprojectionStats) Prune(t time.Time) {
+	s.samples.Prune(t)
+}
+
+// errorStats accumulates the errors of all samples in the time window, in total and per grid cell
+func (s *ReprojectionStats) errorStats() (total ErrorStats, grid [reprojectionGridRows][reprojectionGridCols]ErrorStats) {
+	for _, sample := range s.samples.All() {
+		total.Add(sample.err)
+		grid[sample.row][sample.col].Add(sample.err)
+	}
+	return
+}
+
+// cellSize estimates the image size from the principal point, which is usually close to the image center
+func (s *ReprojectionStats) cellSize() (width float64, height float64) {
+	width = math.Max(1, 2*s.model.PrincipalPointX/reprojectionGridCols)
+	height = math.Max(1, 2*s.model.PrincipalPointY/reprojectionGridRows)
+	return
+}
+
+func clampIndex(i int, n int) int {
+	if i < 0 {
+		return 0
+	}
+	if i >= n {
+		return n - 1
+	}
+	return i
+}
+
+func (s *ReprojectionStats) Snapshot() (snapshot ReprojectionSnapshot) {
+	total, grid := s.errorStats()
+	snapshot.Calibrated = s.model != nil
+	snapshot.Error = total.Snapshot()
+	snapshot.GridSize.Cols = reprojectionGridCols
+	snapshot.GridSize.Rows = reprojectionGridRows
+	if s.model != nil {
+		snapshot.GridSize.CellWidth, snapshot.GridSize.CellHeight = s.cellSize()
+	}
+	snapshot.Grid = make([][]ErrorSnapshot, reprojectionGridRows)
+	for row := range grid {
+		snapshot.Grid[row] = make([]ErrorSnapshot, reprojectionGridCols)
+		for col := range grid[row] {
+			snapshot.Grid[row][col] = grid[row][col].Snapshot()
+		}
+	}
+	return
+}
+
+func (s *ReprojectionStats) String() string {
+	if s.model == nil {
+		return "no calibration"
+	}
+	total, grid := s.errorStats()
+	if total.NumSamples == 0 {
+		return "no samples"
+	}
+	worst := ErrorStats{}
+	worstRow, worstCol := 0, 0
+	for row := range grid {
+		for col := range grid[row] {
+			if grid[row][col].Mean() > worst.Mean() {
+				worst = grid[row][col]
+				worstRow, worstCol = row, col
+			}
+		}
+	}
+	return fmt.Sprintf("mean %.2fpx σ %.2fpx max %.2fpx | worst region %v/%v: %.2fpx (%v samples)",
+		total.Mean(), total.StdDev(), total.Max, worstCol, worstRow, worst.Mean(), total.NumSamples)
+}
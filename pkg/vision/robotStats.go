@@ -1,17 +1,26 @@
 package vision
 
 import (
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
 	"time"
 )
 
 type RobotStats struct {
 	Id RobotId
 	*ObjectStats
+	Orientation *OrientationStats
+	Ids         *IdStats
+	// Phantom is true, if the track was detected at the position of an older track in the same frame
+	// within the time window of the robot ids
+	Phantom bool
+	// tPhantom is the last time the track was detected at the position of an older track
+	tPhantom time.Time
 }
 
-func NewRobotStats(robotId RobotId, detection Detection, timeWindow time.Duration) (s RobotStats) {
+func NewRobotStats(robotId RobotId, detection Detection, timeWindow time.Duration, clock timing.Clock) (s RobotStats) {
 	s.Id = robotId
-	s.ObjectStats = NewObjectStats(detection, timeWindow)
+	s.ObjectStats = NewObjectStats(detection, timeWindow, clock)
+	s.Orientation = NewOrientationStats(timeWindow)
 
 	return s
 }
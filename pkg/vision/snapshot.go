@@ -0,0 +1,218 @@
+package vision
+
+import (
+	"fmt"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/referee"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"math"
+	"time"
+)
+
+// StatsSnapshot is a copy of the current vision statistics that can be serialized
+type StatsSnapshot struct {
+	GameState referee.GameState `json:"gameState"`
+	Cameras   []CamSnapshot     `json:"cameras"`
+	Geometry  GeometrySnapshot  `json:"geometry"`
+	CrossCam  []CamPairSnapshot `json:"crossCam"`
+	// CamSources are the source hosts of each camera
+	CamSources []CamSourcesSnapshot `json:"camSources"`
+	// Duplicates are robot ids that are detected at different places, active ones first
+	Duplicates []DuplicateSnapshot `json:"duplicates"`
+	// AssumeStationary is true, if all objects are treated as stationary for measuring the position noise
+	AssumeStationary bool     `json:"assumeStationary"`
+	Log              []string `json:"log"`
+}
+
+type CamSnapshot struct {
+	CameraId int    `json:"cameraId"`
+	Source   string `json:"source"`
+	// Primary is true, if the source is the primary source of the camera, see CamSources
+	Primary          bool                      `json:"primary"`
+	Frames           timing.FrameStatsSnapshot `json:"frames"`
+	Sequence         timing.SequenceSnapshot   `json:"sequence"`
+	Capture          CaptureSnapshot           `json:"capture"`
+	TimingProcessing timing.TimingSnapshot     `json:"timingProcessing"`
+	TimingReceiving  timing.TimingSnapshot     `json:"timingReceiving"`
+	Reprojection     ReprojectionSnapshot      `json:"reprojection"`
+	NumVisibleBlue   int                       `json:"numVisibleBlue"`
+	NumVisibleYellow int                       `json:"numVisibleYellow"`
+	// OrientationNoise is the mean orientation noise of all robots in radians
+	OrientationNoise    float64 `json:"orientationNoise"`
+	NumOrientationFlips int     `json:"numOrientationFlips"`
+	// Stationary summarizes the position noise of all stationary objects
+	Stationary CamStationarySnapshot `json:"stationary"`
+	// IdStability is the fraction of robot detections with the most frequent id of their track
+	IdStability   float64 `json:"idStability"`
+	NumIdChanges  int     `json:"numIdChanges"`
+	NumIdFlickers int     `json:"numIdFlickers"`
+	NumIdPhantoms int     `json:"numIdPhantoms"`
+	// NumDuplicateIds counts the robot ids detected at different places by this camera, ActiveDuplicateIds only the current ones
+	NumDuplicateIds    int `json:"numDuplicateIds"`
+	ActiveDuplicateIds int `json:"activeDuplicateIds"`
+	// BallBlobs describes the detections of all balls
+	BallBlobs BlobSnapshot     `json:"ballBlobs"`
+	Balls     []ObjectSnapshot `json:"balls"`
+	Robots    []RobotSnapshot  `json:"robots"`
+}
+
+// Name returns the name of the camera, which includes the source if it is not the primary source of the camera
+func (s CamSnapshot) Name() string {
+	if s.Primary {
+		return fmt.Sprintf("camera %d", s.CameraId)
+	}
+	return fmt.Sprintf("camera %d from %v", s.CameraId, s.Source)
+}
+
+type ObjectSnapshot struct {
+	Frames       timing.FrameStatsSnapshot `json:"frames"`
+	Age          time.Duration             `json:"age"`
+	Position     Position2d                `json:"position"`
+	LastDetected time.Time                 `json:"lastDetected"`
+	Stationary   StationarySnapshot        `json:"stationary"`
+	// Blob is only set for balls
+	Blob *BlobSnapshot `json:"blob,omitempty"`
+}
+
+type RobotSnapshot struct {
+	Id    int       `json:"id"`
+	Color TeamColor `json:"color"`
+	ObjectSnapshot
+	Orientation OrientationSnapshot `json:"orientation"`
+	Ids         IdSnapshot          `json:"ids"`
+	// Phantom is true, if the robot was detected at the position of another robot
+	Phantom bool `json:"phantom"`
+}
+
+// Snapshot copies the current statistics. The log is limited to the last maxLogEntries entries.
+func (s *Stats) Snapshot(maxLogEntries int) (snapshot StatsSnapshot) {
+	snapshot = s.snapshot(maxLogEntries)
+	// the noise spectra only depend on the copied positions and are calculated without holding the lock
+	for i := range snapshot.Cameras {
+		snapshot.Cameras[i].calculateSpectra()
+	}
+	return
+}
+
+func (s *Stats) snapshot(maxLogEntries int) (snapshot StatsSnapshot) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	snapshot.GameState = s.GameState
+	snapshot.AssumeStationary = s.AssumeStationary
+	snapshot.Cameras = []CamSnapshot{}
+	for _, key := range s.SortedCamKeys() {
+		camSnapshot := s.CamStats[key].Snapshot(key.CamId)
+		camSnapshot.Source = key.Source
+		camSnapshot.Primary = s.CamSources.IsPrimary(key)
+		if camSnapshot.Primary {
+			camSnapshot.NumDuplicateIds = s.Duplicates.NumDuplicates[key.CamId]
+			camSnapshot.ActiveDuplicateIds = s.Duplicates.NumActive(key.CamId)
+		}
+		snapshot.Cameras = append(snapshot.Cameras, camSnapshot)
+	}
+	setRelativeCapture(snapshot.Cameras)
+
+	snapshot.Geometry = s.Geometry.Snapshot(s.SortedCamIds())
+	snapshot.Geometry.Ignored = map[string]int{}
+	for source, n := range s.IgnoredGeometry {
+		snapshot.Geometry.Ignored[source] = n
+	}
+	snapshot.CrossCam = s.CrossCam.Snapshot()
+	snapshot.CamSources = s.CamSources.Snapshot(s.Clock.Now())
+	snapshot.Duplicates = s.Duplicates.Snapshot()
+
+	snapshot.Log = s.LogList.Latest(maxLogEntries)
+	return
+}
+
+func (s *CamStats) Snapshot(camId int) (snapshot CamSnapshot) {
+	snapshot.CameraId = camId
+	snapshot.Frames = s.FrameStats.Snapshot()
+	snapshot.Sequence = s.Sequence.Snapshot()
+	snapshot.Capture = s.Capture.Snapshot()
+	snapshot.TimingProcessing = s.TimingProcessing.Snapshot()
+	snapshot.TimingReceiving = s.TimingReceiving.Snapshot()
+	snapshot.Reprojection = s.Reprojection.Snapshot()
+	snapshot.NumVisibleBlue = s.NumVisibleRobots(TeamBlue)
+	snapshot.NumVisibleYellow = s.NumVisibleRobots(TeamYellow)
+	snapshot.OrientationNoise = s.OrientationNoise()
+	snapshot.NumOrientationFlips = s.NumOrientationFlips
+	snapshot.IdStability = s.IdStability()
+	snapshot.NumIdChanges = s.NumIdChanges
+	snapshot.NumIdFlickers = s.NumIdFlickers
+	snapshot.NumIdPhantoms = s.NumIdPhantoms
+	snapshot.BallBlobs = s.Blob.Snapshot()
+	var stationary []StationarySnapshot
+	snapshot.Balls = []ObjectSnapshot{}
+	for _, ball := range s.Balls {
+		ballSnapshot := s.objectSnapshot(ball)
+		snapshot.Balls = append(snapshot.Balls, ballSnapshot)
+		stationary = append(stationary, ballSnapshot.Stationary)
+	}
+	snapshot.Robots = []RobotSnapshot{}
+	for _, robot := range s.sortedRobotStats() {
+		robotSnapshot := RobotSnapshot{
+			Id:             robot.Id.Id,
+			Color:          robot.Id.Color,
+			ObjectSnapshot: s.objectSnapshot(robot.ObjectStats),
+			Orientation:    robot.Orientation.Snapshot(),
+			Ids:            robot.Ids.Snapshot(),
+			Phantom:        robot.Phantom,
+		}
+		snapshot.Robots = append(snapshot.Robots, robotSnapshot)
+		stationary = append(stationary, robotSnapshot.Stationary)
+	}
+	snapshot.Stationary = camStationarySnapshot(stationary)
+	return
+}
+
+func (s *CamSnapshot) calculateSpectra() {
+	for i := range s.Balls {
+		s.Balls[i].Stationary.calculateSpectrum()
+	}
+	for i := range s.Robots {
+		s.Robots[i].Stationary.calculateSpectrum()
+	}
+}
+
+// objectSnapshot copies the statistics of an object including its stationary noise
+func (s *CamStats) objectSnapshot(object *ObjectStats) (snapshot ObjectSnapshot) {
+	snapshot = object.Snapshot()
+	snapshot.Stationary = s.stationarySnapshot(object)
+	if object.Blob != nil {
+		blob := object.Blob.Snapshot()
+		snapshot.Blob = &blob
+	}
+	return
+}
+
+func (s *CamStats) stationarySnapshot(object *ObjectStats) StationarySnapshot {
+	if object.Stationary == nil {
+		return StationarySnapshot{}
+	}
+	return object.Stationary.Snapshot(s.statsConfig.AssumeStationary)
+}
+
+// camStationarySnapshot summarizes the noise of the stationary objects
+func camStationarySnapshot(objects []StationarySnapshot) (snapshot CamStationarySnapshot) {
+	sum := 0.0
+	for _, object := range objects {
+		if object.Stationary {
+			snapshot.NumObjects++
+			sum += object.StdDev
+			snapshot.MaxDeviation = math.Max(snapshot.MaxDeviation, object.MaxDeviation)
+		}
+	}
+	if snapshot.NumObjects > 0 {
+		snapshot.StdDev = sum / float64(snapshot.NumObjects)
+	}
+	return
+}
+
+func (s *ObjectStats) Snapshot() (snapshot ObjectSnapshot) {
+	snapshot.Frames = s.FrameStats.Snapshot()
+	snapshot.Age = s.Age()
+	snapshot.Position = s.LastDetection.Pos
+	snapshot.LastDetected = s.LastDetection.Time
+	return
+}
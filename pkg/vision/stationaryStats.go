@@ -0,0 +1,216 @@
+package vision
+
+import (
+	"fmt"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"math"
+	"math/cmplx"
+	"time"
+)
+
+// minStationarySamples is the minimum number of detections within the time window to analyse the noise of an object
+const minStationarySamples = 10
+
+// maxSpectrumBands is the maximum number of frequency bands of the noise spectrum
+const maxSpectrumBands = 16
+
+// StationaryStats collects the positions of an object to measure the noise while it is not moving
+type StationaryStats struct {
+	positions *timing.TimeWindow[Position2d]
+	// radius is the maximum deviation from the mean position in meters for an object to be detected as stationary
+	radius float64
+}
+
+// StationarySnapshot is the position noise of an object in meters, which is only set while it is stationary
+type StationarySnapshot struct {
+	Stationary   bool          `json:"stationary"`
+	Duration     time.Duration `json:"duration"`
+	NumSamples   int           `json:"numSamples"`
+	Mean         Position2d    `json:"mean"`
+	StdDevX      float64       `json:"stdDevX"`
+	StdDevY      float64       `json:"stdDevY"`
+	StdDev       float64       `json:"stdDev"`
+	MaxDeviation float64       `json:"maxDeviation"`
+	// Spectrum is the amplitude of the deviation from the mean position per frequency band
+	Spectrum []SpectrumBand `json:"spectrum"`
+	// dx and dy are the deviations from the mean position, which are kept to calculate the spectrum
+	dx, dy     []float64
+	sampleRate float64
+}
+
+// SpectrumBand is the amplitude of the noise in meters between two frequencies in Hz
+type SpectrumBand struct {
+	MinFrequency float64 `json:"minFrequency"`
+	MaxFrequency float64 `json:"maxFrequency"`
+	Amplitude    float64 `json:"amplitude"`
+}
+
+// CamStationarySnapshot summarizes the position noise of all stationary objects of a camera in meters
+type CamStationarySnapshot struct {
+	NumObjects   int     `json:"numObjects"`
+	StdDev       float64 `json:"stdDev"`
+	MaxDeviation float64 `json:"maxDeviation"`
+}
+
+func NewStationaryStats(timeWindow time.Duration, radius float64) (s *StationaryStats) {
+	s = new(StationaryStats)
+	s.positions = timing.NewTimeWindow[Position2d](timeWindow)
+	s.radius = radius
+	return s
+}
+
+func (s *StationaryStats) Add(t time.Time, pos Position2d) {
+	s.positions.Add(t, pos)
+	s.positions.Prune(t)
+}
+
+func (s *StationaryStats) Clear() {
+	s.positions.Clear()
+}
+
+// Snapshot analyses the positions within the time window. If assumeStationary is false,
+// the object is only considered stationary if the positions cover at least half of the time window
+// and all of them are within the radius around the mean position.
+func (s *StationaryStats) Snapshot(assumeStationary bool) (snapshot StationarySnapshot) {
+	n := s.positions.Len()
+	if n < minStationarySamples {
+		return
+	}
+	duration := s.positions.Span()
+
+	var sumX, sumY float64
+	for _, pos := range s.positions.All() {
+		sumX += float64(pos.X)
+		sumY += float64(pos.Y)
+	}
+	meanX := sumX / float64(n)
+	meanY := sumY / float64(n)
+
+	var sqSumX, sqSumY, maxDeviation float64
+	dx := make([]float64, n)
+	dy := make([]float64, n)
+	for i := 0; i < n; i++ {
+		pos := s.positions.At(i)
+		dx[i] = float64(pos.X) - meanX
+		dy[i] = float64(pos.Y) - meanY
+		sqSumX += dx[i] * dx[i]
+		sqSumY += dy[i] * dy[i]
+		maxDeviation = math.Max(maxDeviation, math.Hypot(dx[i], dy[i]))
+	}
+
+	if !assumeStationary && (duration < s.positions.Duration/2 || maxDeviation > s.radius) {
+		return
+	}
+	snapshot.Stationary = true
+	snapshot.Duration = duration
+	snapshot.NumSamples = n
+	snapshot.Mean = Position2d{X: float32(meanX), Y: float32(meanY)}
+	snapshot.StdDevX = math.Sqrt(sqSumX / float64(n))
+	snapshot.StdDevY = math.Sqrt(sqSumY / float64(n))
+	snapshot.StdDev = math.Sqrt((sqSumX + sqSumY) / float64(n))
+	snapshot.MaxDeviation = maxDeviation
+	if duration > 0 {
+		// the detections are assumed to be equidistant in time
+		snapshot.sampleRate = float64(n-1) / duration.Seconds()
+		snapshot.dx = dx
+		snapshot.dy = dy
+	}
+	return
+}
+
+// calculateSpectrum calculates the spectrum from the deviations that were copied by the snapshot.
+// It is called after the lock of the statistics is released.
+func (s *StationarySnapshot) calculateSpectrum() {
+	if len(s.dx) > 0 {
+		s.Spectrum = spectrum(s.dx, s.dy, s.sampleRate)
+	}
+	s.dx = nil
+	s.dy = nil
+}
+
+// spectrum calculates the amplitude of the deviations per frequency band with a fast Fourier transform
+// of the latest power of two samples
+func spectrum(dx, dy []float64, sampleRate float64) []SpectrumBand {
+	n := 1
+	for n*2 <= len(dx) {
+		n *= 2
+	}
+	// x and y are transformed together as real and imaginary part
+	values := make([]complex128, n)
+	for i := range values {
+		j := len(dx) - n + i
+		values[i] = complex(dx[j], dy[j])
+	}
+	fft(values)
+
+	numFrequencies := n / 2
+	numBands := numFrequencies
+	if numBands > maxSpectrumBands {
+		numBands = maxSpectrumBands
+	}
+	bands := make([]SpectrumBand, numBands)
+	for k := 1; k <= numFrequencies; k++ {
+		// the positive and negative frequency of the combined transform hold the power of x and y,
+		// which is the amplitude of a single sided spectrum
+		power := (sqAbs(values[k]) + sqAbs(values[n-k])) * 2 / float64(n*n)
+		if k == numFrequencies {
+			// the nyquist frequency is its own negative counterpart and is not doubled in a single sided spectrum
+			power /= 4
+		}
+		b := (k - 1) * numBands / numFrequencies
+		frequency := sampleRate * float64(k) / float64(n)
+		if bands[b].MinFrequency == 0 {
+			bands[b].MinFrequency = frequency
+		}
+		bands[b].MaxFrequency = frequency
+		bands[b].Amplitude += power
+	}
+	for b := range bands {
+		bands[b].Amplitude = math.Sqrt(bands[b].Amplitude)
+	}
+	return bands
+}
+
+// fft transforms the values in place with the iterative radix-2 Cooley-Tukey algorithm, len(values) must be a power of two
+func fft(values []complex128) {
+	n := len(values)
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			values[i], values[j] = values[j], values[i]
+		}
+	}
+	for size := 2; size <= n; size *= 2 {
+		step := cmplx.Rect(1, -2*math.Pi/float64(size))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				even := values[start+k]
+				odd := values[start+k+size/2] * w
+				values[start+k] = even + odd
+				values[start+k+size/2] = even - odd
+				w *= step
+			}
+		}
+	}
+}
+
+func sqAbs(c complex128) float64 {
+	return real(c)*real(c) + imag(c)*imag(c)
+}
+
+func (s StationarySnapshot) String() string {
+	if !s.Stationary {
+		return "moving"
+	}
+	return fmt.Sprintf("stationary σ %.1fmm (x %.1fmm, y %.1fmm) max %.1fmm",
+		s.StdDev*1000, s.StdDevX*1000, s.StdDevY*1000, s.MaxDeviation*1000)
+}
+
+func (s CamStationarySnapshot) String() string {
+	return fmt.Sprintf("%v objects | σ %.1fmm | max %.1fmm", s.NumObjects, s.StdDev*1000, s.MaxDeviation*1000)
+}
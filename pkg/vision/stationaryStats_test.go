@@ -0,0 +1,81 @@
+package vision
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+	"time"
+)
+
+func TestStationaryStats_Snapshot(t *testing.T) {
+	stats := NewStationaryStats(time.Second, 0.05)
+	tStart := time.Unix(1000, 0)
+	for i := 0; i < 100; i++ {
+		// oscillate with 1mm in x at 25Hz, sampled with 100Hz
+		x := 1 + 0.001*math.Cos(2*math.Pi*float64(i)/4)
+		stats.Add(tStart.Add(time.Duration(i)*10*time.Millisecond), Position2d{X: float32(x), Y: 2})
+	}
+
+	s := stats.Snapshot(false)
+	s.calculateSpectrum()
+	if !s.Stationary || s.NumSamples != 100 {
+		t.Fatalf("Expected a stationary object with 100 samples, got %v with %v", s.Stationary, s.NumSamples)
+	}
+	if math.Abs(float64(s.Mean.X)-1) > 1e-6 || math.Abs(float64(s.Mean.Y)-2) > 1e-6 {
+		t.Errorf("Expected the mean at (1, 2), got %v", s.Mean)
+	}
+	if math.Abs(s.StdDevX-0.001/math.Sqrt2) > 1e-6 || s.StdDevY > 1e-6 {
+		t.Errorf("Expected a standard deviation of %v in x only, got %v and %v", 0.001/math.Sqrt2, s.StdDevX, s.StdDevY)
+	}
+	if math.Abs(s.MaxDeviation-0.001) > 1e-6 {
+		t.Errorf("Expected a max deviation of 1mm, got %v", s.MaxDeviation)
+	}
+
+	maxBand := SpectrumBand{}
+	for _, band := range s.Spectrum {
+		if band.Amplitude > maxBand.Amplitude {
+			maxBand = band
+		}
+	}
+	if maxBand.MinFrequency > 25.5 || maxBand.MaxFrequency < 24.5 || math.Abs(maxBand.Amplitude-0.001) > 1e-4 {
+		t.Errorf("Expected the noise at 25Hz with 1mm, got %v", maxBand)
+	}
+}
+
+func TestStationaryStats_Moving(t *testing.T) {
+	stats := NewStationaryStats(time.Second, 0.05)
+	tStart := time.Unix(1000, 0)
+	for i := 0; i < 100; i++ {
+		stats.Add(tStart.Add(time.Duration(i)*10*time.Millisecond), Position2d{X: float32(i) * 0.01})
+	}
+	if s := stats.Snapshot(false); s.Stationary {
+		t.Errorf("Expected a moving object, got %v", s)
+	}
+	if s := stats.Snapshot(true); !s.Stationary {
+		t.Errorf("Expected a stationary object if assumed, got %v", s)
+	}
+
+	stats.Clear()
+	if s := stats.Snapshot(true); s.Stationary {
+		t.Errorf("Expected no measurement after clearing, got %v", s)
+	}
+}
+
+func TestFft(t *testing.T) {
+	values := make([]complex128, 16)
+	for i := range values {
+		values[i] = complex(math.Sin(float64(i)*0.7), math.Cos(float64(i*i)))
+	}
+	expected := make([]complex128, len(values))
+	for k := range expected {
+		for i, v := range values {
+			expected[k] += v * cmplx.Rect(1, -2*math.Pi*float64(k*i)/float64(len(values)))
+		}
+	}
+	fft(values)
+	for k := range values {
+		if cmplx.Abs(values[k]-expected[k]) > 1e-9 {
+			t.Errorf("Expected %v at frequency %v, got %v", expected[k], k, values[k])
+		}
+	}
+}
@@ -1,79 +1,347 @@
 package vision
 
 import (
+	"fmt"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/referee"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"math"
 	"sync"
 	"time"
 )
 
 type Stats struct {
 	StatsConfig
-	CamStats map[int]*CamStats
-	tPruned  time.Time
-	LogList  []string
-	Mutex    sync.Mutex
+	// CamStats holds the statistics of each camera per source host
+	CamStats   map[CamKey]*CamStats
+	CamSources *CamSources
+	Geometry   *GeometryStats
+	CrossCam   *CrossCamStats
+	Duplicates *DuplicateStats
+	Coverage   *CoverageStats
+	// GameState is the current state of the game, unknown if no referee messages are received
+	GameState referee.GameState
+	// IgnoredGeometry counts the geometry packets of each source that is not the primary source of any camera
+	IgnoredGeometry map[string]int
+	tPruned         time.Time
+	LogList         *LogList
+	Mutex           sync.Mutex
+	frameListeners  []FrameListener
 }
 
+// FrameListener is called for each processed detection frame
+type FrameListener func(camId int, processingTime time.Duration, receivingTime time.Duration)
+
 func NewStats(statsConfig StatsConfig) (w *Stats) {
 	w = new(Stats)
 	w.StatsConfig = statsConfig
-	w.CamStats = map[int]*CamStats{}
+	if w.Clock == nil {
+		w.Clock = timing.WallClock{}
+	}
+	if w.VisibleRobotQuality == 0 {
+		w.VisibleRobotQuality = defaultVisibleRobotQuality
+	}
+	if w.QualityThresholds == (timing.QualityThresholds{}) {
+		w.QualityThresholds = timing.DefaultQualityThresholds()
+	}
+	if w.Distribution.Percentiles == nil && w.Distribution.HistogramBuckets == nil {
+		w.Distribution = timing.DefaultDistribution()
+	}
+	w.CamStats = map[CamKey]*CamStats{}
+	w.CamSources = NewCamSources(statsConfig.TimeWindowVisibility)
+	w.Geometry = NewGeometryStats()
+	w.IgnoredGeometry = map[string]int{}
+	w.CrossCam = NewCrossCamStats(statsConfig.MaxCrossCamTimeDiff, statsConfig.TimeWindowCrossCam)
+	w.Duplicates = NewDuplicateStats(statsConfig.MaxDuplicateTimeDiff)
+	w.Coverage = NewCoverageStats(statsConfig.CoverageCellSize)
+	w.LogList = NewLogList(logCapacity)
 	return w
 }
 
+// AddFrameListener registers a listener that is called for each processed detection frame while Mutex is locked
+func (s *Stats) AddFrameListener(listener FrameListener) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	s.frameListeners = append(s.frameListeners, listener)
+}
+
 func (s *Stats) Log(tSent time.Time, str string) {
 	timeFormatted := tSent.Format("2006-01-02T15:04:05.000")
-	s.LogList = append(s.LogList, timeFormatted+": "+str)
+	if s.GameState != referee.GameStateUnknown {
+		timeFormatted += " [" + string(s.GameState) + "]"
+	}
+	s.LogList.Add(timeFormatted + ": " + str)
+}
+
+// AddLog adds a log entry from outside of the processing of vision packets
+func (s *Stats) AddLog(t time.Time, str string) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	s.Log(t, str)
+}
+
+// SetGameState sets the current game state that statistics and log entries are tagged with
+func (s *Stats) SetGameState(state referee.GameState) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	s.GameState = state
 }
 
-func (s *Stats) Process(wrapper *SSL_WrapperPacket) {
+// SetAssumeStationary sets whether all objects are treated as stationary and restarts the noise measurement
+func (s *Stats) SetAssumeStationary(assumeStationary bool) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	s.AssumeStationary = assumeStationary
+	for _, camStats := range s.CamStats {
+		camStats.SetAssumeStationary(assumeStationary)
+	}
+	if assumeStationary {
+		s.Log(s.Clock.Now(), "Started noise measurement with all objects assumed to be stationary")
+	} else {
+		s.Log(s.Clock.Now(), "Stopped assuming stationary objects, detecting them automatically")
+	}
+}
+
+// collectObjects returns true, if balls and robots should be included in the statistics
+func (s *Stats) collectObjects() bool {
+	return !s.OnlyDuringPlay || s.GameState.IsRunning()
+}
+
+// Process processes a packet that was received from the given source host
+func (s *Stats) Process(source string, wrapper *SSL_WrapperPacket) {
 	s.Mutex.Lock()
 	if wrapper == nil {
 		for _, camStats := range s.CamStats {
 			camStats.Clear()
 		}
-	} else if wrapper.Detection != nil {
-		camId := int(*wrapper.Detection.CameraId)
-		if _, ok := s.CamStats[camId]; !ok {
-			s.CamStats[camId] = NewCamStats(s.StatsConfig)
+	} else {
+		if wrapper.Detection != nil {
+			key := CamKey{Source: source, CamId: int(*wrapper.Detection.CameraId)}
+			if _, ok := s.CamStats[key]; !ok {
+				s.CamStats[key] = NewCamStats(s.StatsConfig)
+			}
+			s.processCam(key, wrapper.Detection, s.CamStats[key])
+		}
+		if wrapper.Geometry != nil {
+			s.processGeometry(source, wrapper.Geometry)
 		}
-		s.processCam(wrapper.Detection, s.CamStats[camId])
 	}
 	s.Mutex.Unlock()
 }
 
-func (s *Stats) processCam(frame *SSL_DetectionFrame, camStats *CamStats) {
+func (s *Stats) processCam(key CamKey, frame *SSL_DetectionFrame, camStats *CamStats) {
 
+	camId := key.CamId
 	frameId := *frame.FrameNumber
 	processingTime := time.Duration(int64((*frame.TSent - *frame.TCapture) * 1e9))
 
-	sentSec := int64(*frame.TSent)
-	sentNs := int64((*frame.TSent - float64(sentSec)) * 1e9)
-	tSent := time.Unix(sentSec, sentNs)
-	receivingTime := time.Now().Sub(tSent)
+	tSent := timing.UnixTime(*frame.TSent)
+	tCapture := timing.UnixTime(*frame.TCapture)
+	tReceived := s.Clock.Now()
+	receivingTime := tReceived.Sub(tSent)
+
+	for _, event := range s.CamSources.Add(key, tReceived) {
+		s.Log(tSent, event)
+	}
+	// only the primary source of a camera contributes to the statistics that are shared between cameras
+	primary := s.CamSources.IsPrimary(key)
+	camName := s.CamSources.camName(key)
 
 	camStats.TimingProcessing.Add(processingTime)
 	camStats.TimingReceiving.Add(receivingTime)
+	if primary {
+		for _, listener := range s.frameListeners {
+			listener(camId, processingTime, receivingTime)
+		}
+	}
 
 	camStats.FrameStats.Add(frameId, tSent)
+	previousFrameId := camStats.Sequence.LastFrameId()
+	order, numLost := camStats.Sequence.Add(frameId)
+	s.logFrameOrder(tSent, camStats, camName, order, previousFrameId, frameId, numLost)
+	for _, event := range camStats.Capture.Add(tCapture, tReceived, order, numLost) {
+		s.Log(tSent, fmt.Sprintf("%v: %v", camName, event))
+	}
 
-	processRobots(frame.RobotsBlue, TeamBlue, camStats, tSent, frameId)
-	processRobots(frame.RobotsYellow, TeamYellow, camStats, tSent, frameId)
+	if order == timing.FrameDuplicate || !s.collectObjects() {
+		camStats.Prune(tSent)
+		return
+	}
 
+	camStats.Reprojection.SetModel(s.Geometry.Models[camId])
+	s.processRobots(frame.RobotsBlue, TeamBlue, key, camStats, tSent, frameId)
+	s.processRobots(frame.RobotsYellow, TeamYellow, key, camStats, tSent, frameId)
+	if primary {
+		s.crossCamRobots(frame.RobotsBlue, TeamBlue, camId, tCapture)
+		s.crossCamRobots(frame.RobotsYellow, TeamYellow, camId, tCapture)
+		s.duplicateRobots(frame, camId, tSent, tCapture)
+	}
+
+	var ballPositions []Position2d
 	for _, ball := range frame.Balls {
+		ballHeight := defaultBallHeight
+		if ball.GetZ() > 0 {
+			ballHeight = float64(ball.GetZ())
+		}
+		ballWorld := Vector3{X: float64(*ball.X), Y: float64(*ball.Y), Z: ballHeight}
+		camStats.Reprojection.Add(tSent, ballWorld, *ball.PixelX, *ball.PixelY)
+
+		areaRatio := 0.0
+		if model := s.Geometry.Models[camId]; model != nil {
+			if expectedArea := expectedBallArea(model, ballWorld); expectedArea > 0 {
+				areaRatio = float64(ball.GetArea()) / expectedArea
+			}
+		}
+		confidence := float64(ball.GetConfidence())
+		area := float64(ball.GetArea())
+		height := float64(ball.GetZ()) / 1000.0
+		camStats.Blob.Add(tSent, confidence, area, areaRatio, height)
+
 		ballPos := Position2d{X: *ball.X / 1000.0, Y: *ball.Y / 1000.0}
-		ball := camStats.GetBallStats(tSent, ballPos)
-		ball.Add(tSent, frameId, ballPos)
+		ballPositions = append(ballPositions, ballPos)
+		ballStats := camStats.GetBallStats(tSent, ballPos)
+		ballStats.Add(tSent, frameId, ballPos)
+		ballStats.Blob.Add(tSent, confidence, area, areaRatio, height)
+		if primary {
+			s.Coverage.Add(camId, ballPos, ballStats.FrameStats.Quality())
+		}
+	}
+
+	if primary {
+		s.CrossCam.AddBalls(camId, tCapture, ballPositions)
+		s.CrossCam.Prune(tCapture)
 	}
 
 	camStats.Prune(tSent)
 	camStats.Merge()
 }
 
-func processRobots(robots []*SSL_DetectionRobot, teamColor TeamColor, camStats *CamStats, tSent time.Time, frameId uint32) {
+// logFrameOrder logs reset frame numbers immediately and lost, duplicated and reordered frames
+// at most once per frameOrderLogInterval and camera
+func (s *Stats) logFrameOrder(tSent time.Time, camStats *CamStats, camName string, order timing.FrameOrder, previousFrameId uint32, frameId uint32, numLost int) {
+	if order == timing.FrameReset {
+		s.Log(tSent, fmt.Sprintf("%v: frame number reset from %d to %d", camName, previousFrameId, frameId))
+	} else if summary, ok := camStats.frameOrderLog.add(tSent, order, numLost); ok {
+		s.Log(tSent, fmt.Sprintf("%v: %v", camName, summary))
+	}
+}
+
+func (s *Stats) crossCamRobots(robots []*SSL_DetectionRobot, teamColor TeamColor, camId int, tCapture time.Time) {
+	for _, robot := range robots {
+		robotId := NewRobotId(int(*robot.RobotId), teamColor)
+		robotPos := Position2d{X: *robot.X / 1000.0, Y: *robot.Y / 1000.0}
+		s.CrossCam.AddRobot(camId, robotId, tCapture, robotPos, robot.Orientation)
+	}
+}
+
+// duplicateRobots checks the robots of a frame for robot ids that are detected at different places
+func (s *Stats) duplicateRobots(frame *SSL_DetectionFrame, camId int, tSent time.Time, tCapture time.Time) {
+	robots := map[RobotId][]Position2d{}
+	for teamColor, teamRobots := range map[TeamColor][]*SSL_DetectionRobot{TeamBlue: frame.RobotsBlue, TeamYellow: frame.RobotsYellow} {
+		for _, robot := range teamRobots {
+			robotId := NewRobotId(int(*robot.RobotId), teamColor)
+			robots[robotId] = append(robots[robotId], Position2d{X: *robot.X / 1000.0, Y: *robot.Y / 1000.0})
+		}
+	}
+	for _, event := range s.Duplicates.AddFrame(camId, tCapture, robots) {
+		s.Log(tSent, event)
+	}
+	for _, event := range s.Duplicates.Prune(tCapture) {
+		s.Log(tSent, event)
+	}
+}
+
+// processGeometry updates the geometry from the given source, geometry from other hosts that publish the same cameras,
+// like the ssl-vision of a team, is ignored to not mix up the calibrations
+func (s *Stats) processGeometry(source string, geometry *SSL_GeometryData) {
+	tReceived := s.Clock.Now()
+	if !s.CamSources.IsPrimarySource(source) {
+		if s.IgnoredGeometry[source] == 0 {
+			s.Log(tReceived, fmt.Sprintf("Ignoring geometry from %v, which is not the primary source of any camera", source))
+		}
+		s.IgnoredGeometry[source]++
+		return
+	}
+	for _, change := range s.Geometry.Add(tReceived, geometry) {
+		s.Log(tReceived, change)
+	}
+	s.Coverage.SetField(geometry.Field)
+}
+
+func (s *Stats) processRobots(robots []*SSL_DetectionRobot, teamColor TeamColor, key CamKey, camStats *CamStats, tSent time.Time, frameId uint32) {
+	camId := key.CamId
+	primary := s.CamSources.IsPrimary(key)
 	for _, robot := range robots {
 		robotId := NewRobotId(int(*robot.RobotId), teamColor)
 		robotPos := Position2d{X: *robot.X / 1000.0, Y: *robot.Y / 1000.0}
 		robotStats := camStats.GetRobotStats(robotId, tSent, robotPos)
 		robotStats.Add(tSent, frameId, robotPos)
+		s.processRobotId(key, camStats, robotStats, robotId, tSent)
+		if robot.Orientation != nil {
+			if flip, delta := robotStats.Orientation.Add(tSent, float64(*robot.Orientation)); flip {
+				camStats.NumOrientationFlips++
+				s.Log(tSent, fmt.Sprintf("%v: orientation of robot %v%d flipped by %.0f°",
+					s.CamSources.camName(key), teamColor, robotId.Id, delta*180/math.Pi))
+			}
+		}
+		if primary {
+			s.Coverage.Add(camId, robotPos, robotStats.FrameStats.Quality())
+		}
+
+		robotHeight := defaultRobotHeight
+		if robot.GetHeight() > 0 {
+			robotHeight = float64(robot.GetHeight())
+		}
+		camStats.Reprojection.Add(tSent, Vector3{X: float64(*robot.X), Y: float64(*robot.Y), Z: robotHeight}, *robot.PixelX, *robot.PixelY)
+	}
+}
+
+// processRobotId analyses the id of a robot detection and the detections of other ids at the same position
+func (s *Stats) processRobotId(key CamKey, camStats *CamStats, robotStats *RobotStats, robotId RobotId, tSent time.Time) {
+	switch event, from, to := robotStats.Ids.Add(tSent, robotId); event {
+	case IdFlicker:
+		camStats.NumIdFlickers++
+		s.Log(tSent, fmt.Sprintf("%v: robot %v%d misread as %v%d", s.CamSources.camName(key), from.Color, from.Id, to.Color, to.Id))
+	case IdChange:
+		camStats.NumIdChanges++
+		s.Log(tSent, fmt.Sprintf("%v: id of robot changed from %v%d to %v%d", s.CamSources.camName(key), from.Color, from.Id, to.Color, to.Id))
 	}
+	robotStats.Id, _ = robotStats.Ids.Dominant()
+
+	if other := camStats.RobotAtPosition(robotStats); other != nil {
+		// the younger track is the phantom
+		original, phantom := other, robotStats
+		if robotStats.Age() > other.Age() {
+			original, phantom = robotStats, other
+		}
+		original.Ids.AddPhantom(tSent, phantom.Id)
+		camStats.NumIdPhantoms++
+		phantom.tPhantom = tSent
+		if !phantom.Phantom {
+			phantom.Phantom = true
+			s.Log(tSent, fmt.Sprintf("%v: robot %v%d detected at the position of robot %v%d",
+				s.CamSources.camName(key), phantom.Id.Color, phantom.Id.Id, original.Id.Color, original.Id.Id))
+		}
+	}
+	if robotStats.Phantom && tSent.Sub(robotStats.tPhantom) > s.TimeWindowRobotIds {
+		robotStats.Phantom = false
+		s.Log(tSent, fmt.Sprintf("%v: robot %v%d no longer detected at the position of another robot",
+			s.CamSources.camName(key), robotStats.Id.Color, robotStats.Id.Id))
+	}
+}
+
+// SortedCamIds returns the ids of all known cameras in ascending order
+func (s *Stats) SortedCamIds() []int {
+	return sortedKeys(s.CamSources.Primary)
+}
+
+// SortedCamKeys returns the keys of all camera statistics ordered by camera id with the primary source first
+func (s *Stats) SortedCamKeys() []CamKey {
+	return s.CamSources.sortedCamKeys(s.CamStats)
+}
+
+// LogEntriesSince returns all kept log entries starting at the given index and the index of the next entry
+func (s *Stats) LogEntriesSince(index int) ([]string, int) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	return s.LogList.Since(index)
 }
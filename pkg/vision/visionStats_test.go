@@ -0,0 +1,36 @@
+package vision
+
+import (
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"testing"
+	"time"
+)
+
+func TestNewStats_Defaults(t *testing.T) {
+	stats := NewStats(StatsConfig{})
+	if stats.Clock == nil {
+		t.Error("Expected the wall clock by default")
+	}
+	if stats.VisibleRobotQuality != defaultVisibleRobotQuality {
+		t.Errorf("Expected a visible robot quality of %v, got %v", defaultVisibleRobotQuality, stats.VisibleRobotQuality)
+	}
+	if stats.QualityThresholds != timing.DefaultQualityThresholds() {
+		t.Errorf("Expected the default quality thresholds, got %v", stats.QualityThresholds)
+	}
+	if len(stats.Distribution.Percentiles) == 0 || len(stats.Distribution.HistogramBuckets) == 0 {
+		t.Errorf("Expected the default distribution, got %v", stats.Distribution)
+	}
+
+	thresholds := timing.QualityThresholds{Low: 0.5, High: 0.9}
+	stats = NewStats(StatsConfig{VisibleRobotQuality: 0.8, QualityThresholds: thresholds})
+	if stats.VisibleRobotQuality != 0.8 || stats.QualityThresholds != thresholds {
+		t.Errorf("Expected the configured values, got %v and %v", stats.VisibleRobotQuality, stats.QualityThresholds)
+	}
+}
+
+func TestNewStats_TimeDiffs(t *testing.T) {
+	stats := NewStats(StatsConfig{MaxCrossCamTimeDiff: 5 * time.Millisecond, MaxDuplicateTimeDiff: 20 * time.Millisecond})
+	if stats.Duplicates.maxTimeDiff != 20*time.Millisecond {
+		t.Errorf("Expected the duplicate time difference to be independent of the camera overlap, got %v", stats.Duplicates.maxTimeDiff)
+	}
+}
@@ -0,0 +1,134 @@
+package web
+
+import (
+	"embed"
+	"encoding/json"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/inspector"
+	"github.com/gorilla/websocket"
+	"io/fs"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+//go:embed static
+var staticFiles embed.FS
+
+// clientBufferSize is the number of updates that are buffered per client before updates are dropped
+const clientBufferSize = 4
+
+// Dashboard serves a web UI and pushes updates of the statistics to it via WebSocket
+type Dashboard struct {
+	inspector *inspector.Inspector
+	// Interval is the time between two updates
+	Interval time.Duration
+	upgrader websocket.Upgrader
+	clients  map[chan []byte]struct{}
+	mutex    sync.Mutex
+}
+
+func NewDashboard(inspector *inspector.Inspector) (d *Dashboard) {
+	d = new(Dashboard)
+	d.inspector = inspector
+	d.Interval = time.Millisecond * 200
+	d.clients = map[chan []byte]struct{}{}
+	// the dashboard is meant to be opened from other devices, so accept any origin
+	d.upgrader.CheckOrigin = func(*http.Request) bool { return true }
+	return d
+}
+
+// Register registers the static assets on / and the WebSocket endpoint on /ws
+func (d *Dashboard) Register(mux *http.ServeMux) {
+	static, err := fs.Sub(staticFiles, "static")
+	if err != nil {
+		log.Fatal("Could not load static files: ", err)
+	}
+	mux.Handle("/", http.FileServer(http.FS(static)))
+	mux.HandleFunc("/ws", d.handleWebSocket)
+}
+
+// Broadcast sends updates to all connected clients periodically and blocks forever
+func (d *Dashboard) Broadcast() {
+	for {
+		if d.numClients() > 0 {
+			data, err := json.Marshal(NewUpdate(d.inspector.Snapshot()))
+			if err != nil {
+				log.Println("Could not marshal dashboard update: ", err)
+			} else {
+				d.send(data)
+			}
+		}
+		time.Sleep(d.Interval)
+	}
+}
+
+func (d *Dashboard) numClients() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return len(d.clients)
+}
+
+func (d *Dashboard) send(data []byte) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for client := range d.clients {
+		select {
+		case client <- data:
+		default:
+			// the client is too slow, skip this update
+		}
+	}
+}
+
+func (d *Dashboard) addClient() chan []byte {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	client := make(chan []byte, clientBufferSize)
+	d.clients[client] = struct{}{}
+	return client
+}
+
+func (d *Dashboard) removeClient(client chan []byte) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	delete(d.clients, client)
+}
+
+func (d *Dashboard) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := d.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Println("Could not upgrade to WebSocket: ", err)
+		return
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Println("Could not close WebSocket: ", err)
+		}
+	}()
+
+	client := d.addClient()
+	defer d.removeClient(client)
+
+	// read until the client disconnects, incoming messages are ignored
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case data := <-client:
+			if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
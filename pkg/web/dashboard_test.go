@@ -0,0 +1,49 @@
+package web
+
+import (
+	"encoding/json"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/inspector"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/timing"
+	"github.com/gorilla/websocket"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDashboard(t *testing.T) {
+	dashboard := NewDashboard(inspector.NewTestInspector(timing.WallClock{}))
+	dashboard.Interval = time.Millisecond * 10
+	mux := http.NewServeMux()
+	dashboard.Register(mux)
+	go dashboard.Broadcast()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Unexpected status for index: %v", resp.Status)
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var update Update
+	if err := json.Unmarshal(data, &update); err != nil {
+		t.Fatal(err)
+	}
+	if update.Cameras == nil {
+		t.Error("Expected an empty camera list")
+	}
+}
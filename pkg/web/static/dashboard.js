@@ -0,0 +1,279 @@
+'use strict';
+
+// time span of the charts in milliseconds
+const historyDuration = 60000;
+const robotRadius = 90;
+const ballRadius = 21.5;
+const defaultField = {fieldLength: 12000, fieldWidth: 9000, boundaryWidth: 300, goalWidth: 1800, goalDepth: 180, lines: [], arcs: []};
+const palette = ['#e6194b', '#3cb44b', '#ffe119', '#4363d8', '#f58231', '#911eb4', '#46f0f0', '#f032e6',
+    '#bcf60c', '#fabebe', '#008080', '#e6beff', '#9a6324', '#fffac8', '#800000', '#aaffc3'];
+
+const history = {};
+const hiddenCameras = new Set();
+let lastUpdate = null;
+
+function cameraColor(cameraId) {
+    return palette[cameraId % palette.length];
+}
+
+function qualityColor(quality) {
+    const hue = Math.max(0, Math.min(1, quality)) * 120;
+    return `hsl(${hue}, 90%, 50%)`;
+}
+
+function resizeCanvas(canvas) {
+    const ratio = window.devicePixelRatio || 1;
+    const width = Math.round(canvas.clientWidth * ratio);
+    const height = Math.round(canvas.clientHeight * ratio);
+    if (canvas.width !== width || canvas.height !== height) {
+        canvas.width = width;
+        canvas.height = height;
+    }
+    return ratio;
+}
+
+function drawField(update) {
+    const canvas = document.getElementById('field');
+    resizeCanvas(canvas);
+    const ctx = canvas.getContext('2d');
+    const field = update.field || defaultField;
+    const totalLength = field.fieldLength + 2 * field.boundaryWidth;
+    const totalWidth = field.fieldWidth + 2 * field.boundaryWidth;
+    const scale = Math.min(canvas.width / totalLength, canvas.height / totalWidth);
+
+    ctx.setTransform(1, 0, 0, 1, 0, 0);
+    ctx.clearRect(0, 0, canvas.width, canvas.height);
+    // field coordinates in millimeters with the y-axis pointing up
+    ctx.setTransform(scale, 0, 0, -scale, canvas.width / 2, canvas.height / 2);
+
+    ctx.strokeStyle = '#fff';
+    ctx.lineWidth = 10;
+    if (field.lines.length === 0 && field.arcs.length === 0) {
+        ctx.strokeRect(-field.fieldLength / 2, -field.fieldWidth / 2, field.fieldLength, field.fieldWidth);
+    }
+    for (const line of field.lines) {
+        ctx.lineWidth = line.thickness || 10;
+        ctx.beginPath();
+        ctx.moveTo(line.p1.x, line.p1.y);
+        ctx.lineTo(line.p2.x, line.p2.y);
+        ctx.stroke();
+    }
+    for (const arc of field.arcs) {
+        ctx.lineWidth = arc.thickness || 10;
+        ctx.beginPath();
+        ctx.arc(arc.center.x, arc.center.y, arc.radius, arc.a1, arc.a2);
+        ctx.stroke();
+    }
+    ctx.lineWidth = 20;
+    for (const side of [-1, 1]) {
+        const x = side * field.fieldLength / 2;
+        ctx.strokeRect(x, -field.goalWidth / 2, side * field.goalDepth, field.goalWidth);
+    }
+
+    const colorMode = document.getElementById('color-mode').value;
+    for (const cam of update.cameras) {
+        if (hiddenCameras.has(cam.cameraId)) {
+            continue;
+        }
+        for (const robot of cam.robots) {
+            const color = colorMode === 'camera' ? cameraColor(cam.cameraId) : qualityColor(robot.quality);
+            drawObject(ctx, robot.x * 1000, robot.y * 1000, robotRadius, color, robot.team === 'B' ? '#1e88e5' : '#fdd835');
+            drawLabel(ctx, robot.x * 1000, robot.y * 1000, String(robot.id), scale);
+        }
+        for (const ball of cam.balls) {
+            const color = colorMode === 'camera' ? cameraColor(cam.cameraId) : qualityColor(ball.quality);
+            drawObject(ctx, ball.x * 1000, ball.y * 1000, Math.max(ballRadius, 3 / scale), color, '#ff9800');
+        }
+    }
+}
+
+function drawObject(ctx, x, y, radius, fill, stroke) {
+    ctx.beginPath();
+    ctx.arc(x, y, radius, 0, 2 * Math.PI);
+    ctx.globalAlpha = 0.6;
+    ctx.fillStyle = fill;
+    ctx.fill();
+    ctx.globalAlpha = 1;
+    ctx.lineWidth = radius / 4;
+    ctx.strokeStyle = stroke;
+    ctx.stroke();
+}
+
+function drawLabel(ctx, x, y, text, scale) {
+    ctx.save();
+    ctx.translate(x, y);
+    ctx.scale(1 / scale, -1 / scale);
+    ctx.fillStyle = '#000';
+    ctx.font = 'bold 12px sans-serif';
+    ctx.textAlign = 'center';
+    ctx.textBaseline = 'middle';
+    ctx.fillText(text, 0, 0);
+    ctx.restore();
+}
+
+function addHistory(update) {
+    const t = new Date(update.time).getTime();
+    for (const cam of update.cameras) {
+        if (!history[cam.cameraId]) {
+            history[cam.cameraId] = [];
+        }
+        history[cam.cameraId].push({t: t, fps: cam.fps, latency: cam.latency * 1000, quality: cam.quality * 100});
+    }
+    for (const cameraId in history) {
+        const samples = history[cameraId];
+        while (samples.length > 0 && samples[0].t < t - historyDuration) {
+            samples.shift();
+        }
+        if (samples.length === 0) {
+            delete history[cameraId];
+        }
+    }
+    return t;
+}
+
+function drawChart(canvasId, key, tNow) {
+    const canvas = document.getElementById(canvasId);
+    const ratio = resizeCanvas(canvas);
+    const ctx = canvas.getContext('2d');
+    ctx.setTransform(1, 0, 0, 1, 0, 0);
+    ctx.clearRect(0, 0, canvas.width, canvas.height);
+
+    let max = 0;
+    for (const cameraId in history) {
+        for (const sample of history[cameraId]) {
+            max = Math.max(max, sample[key]);
+        }
+    }
+    max = max > 0 ? max * 1.1 : 1;
+
+    const margin = 30 * ratio;
+    const width = canvas.width - margin;
+    const height = canvas.height;
+    ctx.fillStyle = '#aaa';
+    ctx.font = `${10 * ratio}px sans-serif`;
+    ctx.fillText(max.toFixed(1), 2, 10 * ratio);
+    ctx.fillText('0', 2, height - 2);
+
+    for (const cameraId in history) {
+        if (hiddenCameras.has(Number(cameraId))) {
+            continue;
+        }
+        ctx.strokeStyle = cameraColor(Number(cameraId));
+        ctx.lineWidth = ratio;
+        ctx.beginPath();
+        history[cameraId].forEach((sample, i) => {
+            const x = margin + width * (1 - (tNow - sample.t) / historyDuration);
+            const y = height * (1 - sample[key] / max);
+            if (i === 0) {
+                ctx.moveTo(x, y);
+            } else {
+                ctx.lineTo(x, y);
+            }
+        });
+        ctx.stroke();
+    }
+}
+
+function updateCameraFilter(update) {
+    const container = document.getElementById('camera-filter');
+    const ids = update.cameras.map(cam => cam.cameraId).join(',');
+    if (container.dataset.ids === ids) {
+        return;
+    }
+    container.dataset.ids = ids;
+    container.innerHTML = '';
+    for (const cam of update.cameras) {
+        const label = document.createElement('label');
+        const checkbox = document.createElement('input');
+        checkbox.type = 'checkbox';
+        checkbox.checked = !hiddenCameras.has(cam.cameraId);
+        checkbox.addEventListener('change', () => {
+            if (checkbox.checked) {
+                hiddenCameras.delete(cam.cameraId);
+            } else {
+                hiddenCameras.add(cam.cameraId);
+            }
+            render();
+        });
+        const swatch = document.createElement('span');
+        swatch.className = 'swatch';
+        swatch.style.background = cameraColor(cam.cameraId);
+        label.append(checkbox, swatch, `Camera ${cam.cameraId}`);
+        container.append(label);
+    }
+}
+
+function updateTable(update) {
+    const body = document.querySelector('#cameras tbody');
+    body.innerHTML = '';
+    for (const cam of update.cameras) {
+        const row = document.createElement('tr');
+        const values = [
+            cam.cameraId,
+            cam.fps.toFixed(1),
+            (cam.quality * 100).toFixed(0) + '%',
+            (cam.latency * 1000).toFixed(1) + 'ms',
+            (cam.processing * 1000).toFixed(1) + 'ms',
+            cam.robots.length,
+            cam.balls.length,
+        ];
+        for (const value of values) {
+            const cell = document.createElement('td');
+            cell.textContent = value;
+            row.append(cell);
+        }
+        row.cells[0].style.color = cameraColor(cam.cameraId);
+        row.cells[2].style.color = qualityColor(cam.quality);
+        body.append(row);
+    }
+}
+
+function updateStatus(update) {
+    document.getElementById('game-state').textContent = update.gameState || 'no game controller';
+    const alerts = document.getElementById('alerts');
+    alerts.innerHTML = '';
+    for (const alert of update.alerts) {
+        const item = document.createElement('li');
+        item.textContent = alert;
+        alerts.append(item);
+    }
+}
+
+function render() {
+    if (lastUpdate === null) {
+        return;
+    }
+    const tNow = new Date(lastUpdate.time).getTime();
+    drawField(lastUpdate);
+    drawChart('chart-fps', 'fps', tNow);
+    drawChart('chart-latency', 'latency', tNow);
+    drawChart('chart-quality', 'quality', tNow);
+}
+
+function connect() {
+    const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
+    const socket = new WebSocket(`${protocol}//${window.location.host}/ws`);
+    const connection = document.getElementById('connection');
+    socket.onopen = () => {
+        connection.textContent = 'connected';
+        connection.className = 'status connected';
+    };
+    socket.onclose = () => {
+        connection.textContent = 'disconnected';
+        connection.className = 'status disconnected';
+        setTimeout(connect, 1000);
+    };
+    socket.onmessage = event => {
+        const update = JSON.parse(event.data);
+        addHistory(update);
+        lastUpdate = update;
+        updateCameraFilter(update);
+        updateTable(update);
+        updateStatus(update);
+        render();
+    };
+}
+
+document.getElementById('color-mode').addEventListener('change', render);
+window.addEventListener('resize', render);
+connect();
@@ -0,0 +1,81 @@
+package web
+
+import (
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/inspector"
+	"github.com/RoboCup-SSL/ssl-quality-inspector/pkg/vision"
+	"time"
+)
+
+// Update is the message that is pushed to the dashboard periodically.
+// Field dimensions are in millimeters, detection positions in meters.
+type Update struct {
+	Time      time.Time             `json:"time"`
+	GameState string                `json:"gameState"`
+	Field     *vision.FieldSnapshot `json:"field"`
+	Cameras   []CameraUpdate        `json:"cameras"`
+	Alerts    []string              `json:"alerts"`
+}
+
+// CameraUpdate contains the current statistics and detections of a single camera, durations are in seconds
+type CameraUpdate struct {
+	CameraId   int         `json:"cameraId"`
+	Fps        float32     `json:"fps"`
+	Quality    float64     `json:"quality"`
+	Latency    float64     `json:"latency"`
+	Processing float64     `json:"processing"`
+	Balls      []Detection `json:"balls"`
+	Robots     []Detection `json:"robots"`
+}
+
+// Detection is a ball or robot that is currently tracked by a camera, the team is B or Y for robots
+type Detection struct {
+	X       float32 `json:"x"`
+	Y       float32 `json:"y"`
+	Quality float64 `json:"quality"`
+	Team    string  `json:"team,omitempty"`
+	Id      int     `json:"id"`
+}
+
+func NewUpdate(snapshot inspector.Snapshot) (u Update) {
+	u.Time = snapshot.Time
+	u.GameState = string(snapshot.Vision.GameState)
+	u.Field = snapshot.Vision.Geometry.Field
+	u.Cameras = []CameraUpdate{}
+	for _, cam := range snapshot.Vision.Cameras {
+		if !cam.Primary {
+			// the field view shows each camera once, other sources are shown by the API and terminal UI
+			continue
+		}
+		camUpdate := CameraUpdate{
+			CameraId:   cam.CameraId,
+			Fps:        cam.Frames.Fps,
+			Quality:    cam.Frames.Quality,
+			Latency:    cam.TimingReceiving.Median.Seconds(),
+			Processing: cam.TimingProcessing.Median.Seconds(),
+			Balls:      []Detection{},
+			Robots:     []Detection{},
+		}
+		for i, ball := range cam.Balls {
+			camUpdate.Balls = append(camUpdate.Balls, newDetection(ball, "", i))
+		}
+		for _, robot := range cam.Robots {
+			camUpdate.Robots = append(camUpdate.Robots, newDetection(robot.ObjectSnapshot, string(robot.Color), robot.Id))
+		}
+		u.Cameras = append(u.Cameras, camUpdate)
+	}
+	u.Alerts = []string{}
+	for _, alert := range snapshot.Alerts {
+		u.Alerts = append(u.Alerts, alert.Message)
+	}
+	return
+}
+
+func newDetection(object vision.ObjectSnapshot, team string, id int) Detection {
+	return Detection{
+		X:       object.Position.X,
+		Y:       object.Position.Y,
+		Quality: object.Frames.Quality,
+		Team:    team,
+		Id:      id,
+	}
+}